@@ -0,0 +1,116 @@
+package caching
+
+import (
+	"context"
+	"contracts/pb"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type cachedProfile struct {
+	res     *pb.GetProfileResponse
+	expires time.Time
+}
+
+// ProfileCache keeps the profiles read through the gateway for a short
+// time, and drops them once updated or deleted through the gateway.
+// Invalidation is local to the replica that served the update: other
+// replicas keep serving their copy until it expires, so the TTL bounds how
+// stale a profile can be when the gateway runs several replicas.
+type ProfileCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	profiles map[uint32]cachedProfile
+	// generation changes on every invalidation, so that a read started
+	// before an update doesn't store the old profile
+	generation uint64
+	lastSweep  time.Time
+}
+
+// NewProfileCache keeps profiles for ttl, a zero ttl disables the cache.
+func NewProfileCache(ttl time.Duration) *ProfileCache {
+	return &ProfileCache{
+		ttl:       ttl,
+		profiles:  map[uint32]cachedProfile{},
+		lastSweep: time.Now(),
+	}
+}
+
+// UnaryClientInterceptor answers GetProfile from the cache, and invalidates
+// the profiles changed by UpdateProfile and DeleteProfile.
+func (pc *ProfileCache) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if pc.ttl <= 0 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	switch method {
+	case pb.ProfileService_GetProfile_FullMethodName:
+		id := req.(*pb.GetProfileRequest).GetId()
+		cached, generation := pc.get(id)
+		if cached != nil {
+			proto.Merge(reply.(*pb.GetProfileResponse), cached)
+			return nil
+		}
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		pc.set(id, proto.Clone(reply.(*pb.GetProfileResponse)).(*pb.GetProfileResponse), generation)
+		return nil
+
+	case pb.ProfileService_UpdateProfile_FullMethodName:
+		// failed calls may have changed the profile too
+		defer pc.invalidate(req.(*pb.UpdateProfileRequest).GetId())
+	case pb.ProfileService_DeleteProfile_FullMethodName:
+		defer pc.invalidate(req.(*pb.DeleteProfileRequest).GetId())
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (pc *ProfileCache) get(id uint32) (*pb.GetProfileResponse, uint64) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	cached, ok := pc.profiles[id]
+	if !ok || !time.Now().Before(cached.expires) {
+		return nil, pc.generation
+	}
+	return cached.res, pc.generation
+}
+
+func (pc *ProfileCache) set(id uint32, res *pb.GetProfileResponse, generation uint64) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if generation != pc.generation {
+		return
+	}
+	now := time.Now()
+	pc.sweep(now)
+	pc.profiles[id] = cachedProfile{res: res, expires: now.Add(pc.ttl)}
+}
+
+func (pc *ProfileCache) invalidate(id uint32) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	pc.generation++
+	delete(pc.profiles, id)
+}
+
+// sweep drops the expired profiles, once per TTL.
+func (pc *ProfileCache) sweep(now time.Time) {
+	if now.Sub(pc.lastSweep) < pc.ttl {
+		return
+	}
+	pc.lastSweep = now
+
+	for id, cached := range pc.profiles {
+		if !now.Before(cached.expires) {
+			delete(pc.profiles, id)
+		}
+	}
+}
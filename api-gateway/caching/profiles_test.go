@@ -0,0 +1,98 @@
+package caching
+
+import (
+	"context"
+	"contracts/pb"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// fakeProfiles stands for profile-service: it counts the calls that reach
+// it and answers GetProfile with the current bio.
+type fakeProfiles struct {
+	calls int
+	bio   string
+}
+
+func (f *fakeProfiles) invoke(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	f.calls++
+	if res, ok := reply.(*pb.GetProfileResponse); ok {
+		res.Profile = &pb.Profile{Id: req.(*pb.GetProfileRequest).GetId(), Bio: f.bio}
+	}
+	return nil
+}
+
+func (f *fakeProfiles) getProfile(t *testing.T, pc *ProfileCache, id uint32) *pb.Profile {
+	t.Helper()
+	res := &pb.GetProfileResponse{}
+	err := pc.UnaryClientInterceptor(context.Background(), pb.ProfileService_GetProfile_FullMethodName, &pb.GetProfileRequest{Id: id}, res, nil, f.invoke)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Profile
+}
+
+func TestProfileCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		method    string
+		req       any
+		reply     any
+		wantCalls int
+		wantBio   string
+	}{
+		{name: "cached", ttl: time.Minute, wantCalls: 1, wantBio: "old"},
+		{name: "disabled", ttl: 0, wantCalls: 2, wantBio: "new"},
+		{name: "invalidated by an update", ttl: time.Minute, method: pb.ProfileService_UpdateProfile_FullMethodName, req: &pb.UpdateProfileRequest{Id: 1}, reply: &pb.UpdateProfileResponse{}, wantCalls: 3, wantBio: "new"},
+		{name: "invalidated by a deletion", ttl: time.Minute, method: pb.ProfileService_DeleteProfile_FullMethodName, req: &pb.DeleteProfileRequest{Id: 1}, reply: &pb.DeleteProfileResponse{}, wantCalls: 3, wantBio: "new"},
+		{name: "update of another profile", ttl: time.Minute, method: pb.ProfileService_UpdateProfile_FullMethodName, req: &pb.UpdateProfileRequest{Id: 2}, reply: &pb.UpdateProfileResponse{}, wantCalls: 2, wantBio: "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewProfileCache(tt.ttl)
+			profiles := &fakeProfiles{bio: "old"}
+			profiles.getProfile(t, pc, 1)
+
+			profiles.bio = "new"
+			if tt.method != "" {
+				err := pc.UnaryClientInterceptor(context.Background(), tt.method, tt.req, tt.reply, nil, profiles.invoke)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := profiles.getProfile(t, pc, 1); got.GetBio() != tt.wantBio {
+				t.Errorf("got bio %q, want %q", got.GetBio(), tt.wantBio)
+			}
+			if profiles.calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", profiles.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestProfileCacheReadDuringUpdate(t *testing.T) {
+	pc := NewProfileCache(time.Minute)
+	profiles := &fakeProfiles{bio: "old"}
+
+	// the profile is updated while profile-service answers the read
+	stale := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		err := profiles.invoke(ctx, method, req, reply, cc, opts...)
+		pc.invalidate(1)
+		profiles.bio = "new"
+		return err
+	}
+	res := &pb.GetProfileResponse{}
+	err := pc.UnaryClientInterceptor(context.Background(), pb.ProfileService_GetProfile_FullMethodName, &pb.GetProfileRequest{Id: 1}, res, nil, stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := profiles.getProfile(t, pc, 1); got.GetBio() != "new" {
+		t.Errorf("got bio %q, want %q", got.GetBio(), "new")
+	}
+}
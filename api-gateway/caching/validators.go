@@ -0,0 +1,82 @@
+// Package caching spares backend services the reads they already answered:
+// responses carry validators so clients can revalidate what they hold, and
+// the gateway keeps recent profiles for a short time.
+package caching
+
+import (
+	"contracts/pb"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Validators identify a version of a resource, they are sent as ETag and
+// Last-Modified headers and compared to the If-None-Match and
+// If-Modified-Since headers of conditional requests.
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+}
+
+// Set writes v to header.
+func (v Validators) Set(header http.Header) {
+	if v.ETag != "" {
+		header.Set("ETag", v.ETag)
+	}
+	if !v.LastModified.IsZero() {
+		header.Set("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// ProfileValidators derive from the last update of p. The ETag is weak,
+// the same profile is encoded differently by each API version.
+func ProfileValidators(p *pb.Profile) Validators {
+	updatedAt := p.GetUpdatedAt().AsTime()
+	return Validators{
+		ETag:         fmt.Sprintf(`W/"profile-%d-%d"`, p.GetId(), updatedAt.UnixNano()),
+		LastModified: updatedAt,
+	}
+}
+
+// ProfileResponseValidators returns the validators of the profile of a
+// GetProfileResponse.
+func ProfileResponseValidators(resp proto.Message) (Validators, bool) {
+	res, ok := resp.(*pb.GetProfileResponse)
+	if !ok || res.GetProfile().GetUpdatedAt() == nil {
+		return Validators{}, false
+	}
+	return ProfileValidators(res.Profile), true
+}
+
+// NotModified tells whether the representation with the validators set in
+// header is the one the client of r holds already. If-Modified-Since is
+// only used without If-None-Match, as it is less precise.
+func NotModified(r *http.Request, header http.Header) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// weak comparison, as GET requests allow
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
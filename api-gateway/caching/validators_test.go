@@ -0,0 +1,78 @@
+package caching
+
+import (
+	"contracts/pb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestProfileValidators(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 5, time.UTC)
+	profile := &pb.Profile{Id: 7, UpdatedAt: timestamppb.New(updatedAt)}
+
+	v := ProfileValidators(profile)
+	if want := `W/"profile-7-1714564800000000005"`; v.ETag != want {
+		t.Errorf("got ETag %s, want %s", v.ETag, want)
+	}
+	if !v.LastModified.Equal(updatedAt) {
+		t.Errorf("got Last-Modified %v, want %v", v.LastModified, updatedAt)
+	}
+
+	updated := ProfileValidators(&pb.Profile{Id: 7, UpdatedAt: timestamppb.New(updatedAt.Add(time.Nanosecond))})
+	if updated.ETag == v.ETag {
+		t.Error("an update doesn't change the ETag")
+	}
+
+	if _, ok := ProfileResponseValidators(&pb.GetProfileResponse{Profile: profile}); !ok {
+		t.Error("no validators for a profile response")
+	}
+	if _, ok := ProfileResponseValidators(&pb.GetProfileResponse{Profile: &pb.Profile{Id: 7}}); ok {
+		t.Error("validators for a profile without update time")
+	}
+	if _, ok := ProfileResponseValidators(&pb.UpdateProfileResponse{}); ok {
+		t.Error("validators for another response")
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	header := http.Header{}
+	Validators{ETag: `W/"profile-7-1"`, LastModified: lastModified}.Set(header)
+
+	tests := []struct {
+		name            string
+		ifNoneMatch     string
+		ifModifiedSince string
+		want            bool
+	}{
+		{name: "unconditional", want: false},
+		{name: "same ETag", ifNoneMatch: `W/"profile-7-1"`, want: true},
+		{name: "strong form of the ETag", ifNoneMatch: `"profile-7-1"`, want: true},
+		{name: "ETag among others", ifNoneMatch: `"other", W/"profile-7-1"`, want: true},
+		{name: "any ETag", ifNoneMatch: "*", want: true},
+		{name: "other ETag", ifNoneMatch: `W/"profile-7-0"`, want: false},
+		{name: "other ETag modified earlier", ifNoneMatch: `W/"profile-7-0"`, ifModifiedSince: lastModified.Format(http.TimeFormat), want: false},
+		{name: "not modified since", ifModifiedSince: lastModified.Format(http.TimeFormat), want: true},
+		{name: "modified since", ifModifiedSince: lastModified.Add(-time.Second).Format(http.TimeFormat), want: false},
+		{name: "invalid date", ifModifiedSince: "yesterday", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/profiles/7", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				r.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			if got := NotModified(r, header); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
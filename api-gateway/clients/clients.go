@@ -0,0 +1,11 @@
+package clients
+
+import (
+	"api-gateway/transcode"
+
+	"google.golang.org/grpc"
+)
+
+// transcoded is the dial option of the services with RPCs served by the
+// transcoding gateway, see transcode.UnaryClientInterceptor.
+var transcoded = grpc.WithChainUnaryInterceptor(transcode.UnaryClientInterceptor)
@@ -0,0 +1,19 @@
+package clients
+
+import (
+	shared "contracts/clients"
+	"contracts/pb"
+	"log"
+	"os"
+)
+
+func NewLogClient() pb.LogServiceClient {
+	addr := os.Getenv("LOGS_SERVICE_URL")
+	log.Printf("logs service url: %s", addr)
+	client, err := shared.NewLogClient(addr)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+
+	return client
+}
@@ -0,0 +1,19 @@
+package clients
+
+import (
+	shared "contracts/clients"
+	"contracts/pb"
+	"log"
+	"os"
+)
+
+func NewMatchClient() pb.MatchServiceClient {
+	addr := os.Getenv("DATE_SERVICE_URL")
+	log.Printf("match service url: %s", addr)
+	client, err := shared.NewMatchClient(addr)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+
+	return client
+}
@@ -1,37 +1,36 @@
 package clients
 
 import (
-	pb "api-gateway/pb/generated"
-	"crypto/tls"
-	"crypto/x509"
+	"api-gateway/caching"
+	shared "contracts/clients"
+	"contracts/pb"
 	"log"
 	"os"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 func NewProfileClient() pb.ProfileServiceClient {
 	addr := os.Getenv("PROFILE_SERVICE_URL")
 	log.Printf("profile service url: %s", addr)
-	// Set up a connection to the server.
-	opts := []grpc.DialOption{}
-	systemRoots, err := x509.SystemCertPool()
-	if err != nil {
-		log.Fatalf("filed to get certs: %v", err)
+
+	// profiles are cached for PROFILE_CACHE_TTL, default 30 seconds, 0
+	// disables the cache
+	ttl := 30 * time.Second
+	if s := os.Getenv("PROFILE_CACHE_TTL"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			log.Fatalf("invalid PROFILE_CACHE_TTL: %v", err)
+		}
+		ttl = d
 	}
-	cred := credentials.NewTLS(&tls.Config{
-		RootCAs: systemRoots,
-	})
-	opts = append(opts, grpc.WithTransportCredentials(cred))
-	conn, err := grpc.NewClient(addr, opts...)
-	// conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	cache := caching.NewProfileCache(ttl)
 
+	client, err := shared.NewProfileClient(addr, transcoded, grpc.WithChainUnaryInterceptor(cache.UnaryClientInterceptor))
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 
-	client := pb.NewProfileServiceClient(conn)
-
 	return client
 }
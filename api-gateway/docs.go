@@ -0,0 +1,175 @@
+package main
+
+import (
+	"api-gateway/exports"
+	"api-gateway/handlers"
+	"api-gateway/middlewares"
+	"api-gateway/openapi"
+	"api-gateway/transcode"
+	"api-gateway/utils"
+	"api-gateway/versioning"
+	"contracts/pb"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var apiInfo = openapi.Info{
+	Title:       "dating-apps-be API",
+	Version:     "1.0.0",
+	Description: "HTTP API of the api-gateway. Request bodies are checked against the validate.rules of the proto contracts before reaching a service.",
+}
+
+// operations documents the hand-written routes registered by newServer,
+// transcoded RPCs are documented from their HTTP bindings by rpcOperations.
+// Fields filled in by the gateway, from the authenticated user, the path or
+// headers, are left out of request bodies.
+var operations = []openapi.Operation{
+	{Method: http.MethodPost, Path: "/payment-callback", Tag: "SubPayment", Public: true,
+		Summary: "Complete a payment, called by Xendit with the x-callback-token header",
+		Request: &pb.CompletePaymentReq{}, Omit: []string{"callback_token"},
+		Response: &pb.CompletePaymentResp{}},
+
+	// the logged in user
+	{Method: http.MethodGet, Path: "/users/me", Tag: "me", Summary: "Get the user with their profile and active subscription",
+		Response: handlers.MeResponse{}},
+	{Method: http.MethodPatch, Path: "/users/me", Tag: "me", Summary: "Update the username or email",
+		Request: handlers.UpdateMeRequest{}, Response: &pb.UpdateUserResponse{}},
+	{Method: http.MethodGet, Path: "/users/me/profile", Tag: "me", Summary: "Get the profile",
+		Response: &pb.GetProfileResponse{}, Conditional: true},
+	{Method: http.MethodPut, Path: "/users/me/profile", Tag: "me", Summary: "Update the profile, fields left out are not changed",
+		Request: &pb.UpdateProfileRequest{}, Omit: []string{"id"}, Response: &pb.UpdateProfileResponse{}},
+	{Method: http.MethodGet, Path: "/users/me/subscription", Tag: "me", Summary: "Get the active subscription and past ones",
+		Response: handlers.SubscriptionResponse{}},
+	{Method: http.MethodPost, Path: "/users/me/export", Tag: "me", Summary: "Start an export of every data kept about the user",
+		Status: http.StatusAccepted, Response: exports.Job{}},
+	{Method: http.MethodGet, Path: "/users/me/export/:id", Tag: "me", Summary: "Get the status of an export",
+		Response: exports.Job{}},
+	{Method: http.MethodGet, Path: "/users/me/export/:id/download", Tag: "me", Summary: "Download a completed export",
+		ContentType: "application/zip"},
+
+	// docs
+	{Method: http.MethodGet, Path: "/openapi.json", Tag: "docs", Public: true, Summary: "This document",
+		Response: map[string]any{}, ContentType: openapi.MIMEOpenAPIJSON},
+	{Method: http.MethodGet, Path: "/docs", Tag: "docs", Public: true, Summary: "Browse this document",
+		ContentType: "text/html"},
+}
+
+// apiDocument is the OpenAPI document of every route registered by
+// newServer for version, main_test.go fails when one is missing.
+func apiDocument(gateway *transcode.Gateway, version versioning.Version) map[string]any {
+	info := apiInfo
+	info.BasePath = version.Prefix
+	if version.Prefix != "" {
+		info.Version += "-" + version.Name
+	}
+	if version.Envelope {
+		info.Meta = versioning.Meta{}
+	}
+	if version.Deprecated && version.Successor != "" {
+		info.Description += fmt.Sprintf(" This version is deprecated, use %s instead.", version.Successor)
+	}
+
+	ops := append(rpcOperations(gateway), operations...)
+	for i, op := range ops {
+		if op.Method == http.MethodPost || op.Method == http.MethodPatch {
+			ops[i].Header = append(op.Header, idempotencyKey)
+		}
+	}
+	return openapi.Document(info, ops, utils.AppError{})
+}
+
+// idempotencyKey is accepted by every POST and PATCH route, see
+// middlewares.Idempotency.
+var idempotencyKey = openapi.Param{
+	Name: middlewares.HeaderIdempotencyKey,
+	Type: "string",
+	Description: "Unique key of the request, up to 255 characters. Retries with the same key are answered with the first response, " +
+		"with an Idempotent-Replayed header. Reusing a key for another request answers 422.",
+}
+
+// rpcOperations documents the routes of the transcoded RPCs. Fields bound
+// to the path or set to the caller are left out of request bodies, those of
+// requests without body are read from the query string.
+func rpcOperations(gateway *transcode.Gateway) []openapi.Operation {
+	ops := []openapi.Operation{}
+	for _, route := range gateway.Routes() {
+		policy := gateway.Policy(route)
+		omit := append(append([]string{}, route.PathParams...), policy.Caller...)
+
+		op := openapi.Operation{
+			Method:      route.Method,
+			Path:        route.Path,
+			Tag:         string(route.RPC.Parent().Name()),
+			Summary:     summary(string(route.RPC.Name())),
+			Public:      policy.Public,
+			Status:      policy.Status,
+			Response:    dynamicpb.NewMessage(route.RPC.Output()),
+			Conditional: policy.Validators != nil,
+		}
+		fields := fieldsBut(route.RPC.Input(), omit)
+		switch {
+		case route.Body == "":
+			for _, fd := range fields {
+				op.Query = append(op.Query, openapi.Param{Name: string(fd.Name()), Type: paramType(fd)})
+			}
+		case len(fields) > 0:
+			op.Request = dynamicpb.NewMessage(route.RPC.Input())
+			op.Omit = omit
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// summary turns the name of an RPC into a sentence, "LoginWithOIDC" becomes
+// "Login with OIDC".
+func summary(rpc string) string {
+	words := []string{}
+	runes := []rune(rpc)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) ||
+			unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		if !boundary {
+			continue
+		}
+		word := string(runes[start:i])
+		if len(words) > 0 && strings.ToUpper(word) != word {
+			word = strings.ToLower(word)
+		}
+		words = append(words, word)
+		start = i
+	}
+	return strings.Join(words, " ")
+}
+
+// fieldsBut returns the fields of md not named in omit.
+func fieldsBut(md protoreflect.MessageDescriptor, omit []string) []protoreflect.FieldDescriptor {
+	fields := []protoreflect.FieldDescriptor{}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if !slices.Contains(omit, string(fd.Name())) {
+			fields = append(fields, fd)
+		}
+	}
+	return fields
+}
+
+func paramType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind:
+		return "string"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "number"
+	}
+	return "integer"
+}
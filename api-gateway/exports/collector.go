@@ -0,0 +1,216 @@
+package exports
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"contracts/pb"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// page size used when walking paginated backend RPCs
+const pageSize = 100
+
+// Collector gathers everything the backend services hold about a user.
+type Collector struct {
+	UserClient    pb.UserServiceClient
+	ProfileClient pb.ProfileServiceClient
+	DateClient    pb.SwipeServiceClient
+	MatchClient   pb.MatchServiceClient
+	PaymentClient pb.SubPaymentClient
+	LogClient     pb.LogServiceClient
+}
+
+type ManifestFile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Records     int    `json:"records"`
+	SHA256      string `json:"sha256"`
+}
+
+type Manifest struct {
+	UserID      uint32         `json:"user_id"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Files       []ManifestFile `json:"files"`
+}
+
+type section struct {
+	name        string
+	description string
+	records     int
+	data        any
+}
+
+// Collect fetches the user's data from every service and returns it as a
+// zip archive of JSON files plus a manifest.json describing them.
+func (c *Collector) Collect(ctx context.Context, userID uint32) ([]byte, error) {
+	sections := []section{}
+
+	user, err := c.UserClient.GetUser(ctx, &pb.GetUserRequest{Id: userID})
+	if err != nil {
+		return nil, fmt.Errorf("users-service: %w", err)
+	}
+	sections = append(sections, section{"user.json", "Account details", 1, user.User})
+
+	profile, err := c.collectProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("profiles-service: %w", err)
+	}
+	records := 0
+	if profile != nil {
+		records = 1
+	}
+	sections = append(sections, section{"profile.json", "Dating profile", records, profile})
+
+	swipes, err := c.collectSwipes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("date-service swipes: %w", err)
+	}
+	sections = append(sections, section{"swipes.json", "Swipes made by the user", len(swipes), swipes})
+
+	matches, err := c.collectMatches(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("date-service matches: %w", err)
+	}
+	sections = append(sections, section{"matches.json", "Mutual matches", len(matches), matches})
+
+	subs, err := c.PaymentClient.GetUserSubcriptions(ctx, &pb.GetUserSubcriptionsReq{UserId: int64(userID)})
+	if err != nil {
+		return nil, fmt.Errorf("payment-service: %w", err)
+	}
+	sections = append(sections, section{"subscriptions.json", "Subscriptions and their payments", len(subs.UserSubscriptions), subs.UserSubscriptions})
+
+	logs, err := c.collectLogs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("logs-service: %w", err)
+	}
+	sections = append(sections, section{"activity_logs.json", "Activity recorded for the user", len(logs), logs})
+
+	return buildArchive(userID, sections)
+}
+
+func (c *Collector) collectProfile(ctx context.Context, userID uint32) (*pb.Profile, error) {
+	res, err := c.ProfileClient.GetProfileByUserID(ctx, &pb.GetProfileByUserIDRequest{UserId: userID})
+	if err != nil {
+		// not every user has created a profile
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res.Profile, nil
+}
+
+func (c *Collector) collectSwipes(ctx context.Context, userID uint32) ([]*pb.SwipeAction, error) {
+	swipes := []*pb.SwipeAction{}
+	for offset := uint32(0); ; offset += pageSize {
+		res, err := c.DateClient.GetSwipeHistory(ctx, &pb.GetSwipeHistoryRequest{
+			UserId: userID,
+			Limit:  pageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		swipes = append(swipes, res.Swipes...)
+		if len(res.Swipes) < pageSize {
+			return swipes, nil
+		}
+	}
+}
+
+func (c *Collector) collectMatches(ctx context.Context, userID uint32) ([]*pb.Match, error) {
+	matches := []*pb.Match{}
+	for offset := uint32(0); ; offset += pageSize {
+		res, err := c.MatchClient.GetMatches(ctx, &pb.GetMatchesRequest{
+			UserId: userID,
+			Limit:  pageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, res.Matches...)
+		if len(res.Matches) < pageSize {
+			return matches, nil
+		}
+	}
+}
+
+func (c *Collector) collectLogs(ctx context.Context, userID uint32) ([]*pb.LogEntry, error) {
+	logs := []*pb.LogEntry{}
+	pageToken := ""
+	for {
+		res, err := c.LogClient.GetLogs(ctx, &pb.GetLogsRequest{
+			UserId:    userID,
+			Limit:     pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, res.Logs...)
+		if res.NextPageToken == "" {
+			return logs, nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
+
+func buildArchive(userID uint32, sections []section) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	manifest := Manifest{
+		UserID:      userID,
+		GeneratedAt: time.Now().UTC(),
+		Files:       []ManifestFile{},
+	}
+
+	for _, s := range sections {
+		data, err := json.MarshalIndent(s.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeZipFile(zw, s.name, data); err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Name:        s.name,
+			Description: s.description,
+			Records:     s.records,
+			SHA256:      hex.EncodeToString(sum[:]),
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeZipFile(zw, "manifest.json", data); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
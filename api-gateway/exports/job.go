@@ -0,0 +1,195 @@
+package exports
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+const (
+	// jobTimeout bounds the backend calls of a job, whose context isn't
+	// canceled along the request that started it
+	jobTimeout = 5 * time.Minute
+	// maxJobs bounds the jobs kept at once, archives are held in memory
+	maxJobs = 1000
+)
+
+var (
+	ErrJobNotFound = errors.New("export job not found")
+	ErrJobExpired  = errors.New("export archive has expired")
+	ErrJobNotReady = errors.New("export archive is not ready yet")
+	ErrTooManyJobs = errors.New("too many export jobs, retry later")
+)
+
+// Job is a single data export requested by a user. The archive is kept in
+// memory until ExpiresAt, after which the job is purged.
+type Job struct {
+	ID          string     `json:"id"`
+	UserID      uint32     `json:"user_id"`
+	Status      Status     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+
+	archive []byte
+}
+
+// Manager runs export jobs in the background and keeps their archives
+// around until they expire. Jobs live in the memory of the replica that
+// started them: they are lost on restart, and polled on another replica
+// they are not found. Exports need a single gateway replica, or sticky
+// sessions routing a user to the same one.
+type Manager struct {
+	collector *Collector
+	ttl       time.Duration
+
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+func NewManager(collector *Collector, ttl time.Duration) *Manager {
+	m := &Manager{
+		collector: collector,
+		ttl:       ttl,
+		jobs:      map[string]*Job{},
+	}
+	go m.purgeExpired()
+	return m
+}
+
+// Start registers a new export job for the user and builds the archive in
+// the background. ctx must carry the caller's credentials for the backend
+// services and must not be canceled along the HTTP request, the job runs
+// for jobTimeout at most. A user has a single job in progress at a time,
+// starting another one returns it.
+func (m *Manager) Start(ctx context.Context, userID uint32) (Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	m.mu.Lock()
+	for _, job := range m.jobs {
+		if job.UserID == userID && (job.Status == StatusPending || job.Status == StatusRunning) {
+			m.mu.Unlock()
+			return *job, nil
+		}
+	}
+	if len(m.jobs) >= maxJobs {
+		m.mu.Unlock()
+		return Job{}, ErrTooManyJobs
+	}
+
+	job := &Job{
+		ID:        id,
+		UserID:    userID,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}
+	m.jobs[id] = job
+	m.mu.Unlock()
+
+	go m.run(ctx, job)
+
+	return *job, nil
+}
+
+// Get returns a snapshot of the job with the given id.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return *job, nil
+}
+
+// Archive returns the zip archive of a completed job.
+func (m *Manager) Archive(id string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	if job.ExpiresAt != nil && time.Now().After(*job.ExpiresAt) {
+		return nil, ErrJobExpired
+	}
+	if job.Status != StatusCompleted {
+		return nil, ErrJobNotReady
+	}
+	return job.archive, nil
+}
+
+func (m *Manager) run(ctx context.Context, job *Job) {
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	m.setStatus(job, StatusRunning)
+
+	archive, err := m.collector.Collect(ctx, job.UserID)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	job.CompletedAt = &now
+	if err != nil {
+		log.Printf("export job %s for user %d failed: %s", job.ID, job.UserID, err.Error())
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = StatusCompleted
+		job.archive = archive
+	}
+	expiresAt := now.Add(m.ttl)
+	job.ExpiresAt = &expiresAt
+}
+
+func (m *Manager) setStatus(job *Job, status Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.Status = status
+}
+
+// purgeExpired periodically drops jobs whose archive has expired.
+func (m *Manager) purgeExpired() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		m.mu.Lock()
+		for id, job := range m.jobs {
+			if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+				delete(m.jobs, id)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,33 @@
+package exports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestManagerStartLimitsJobs(t *testing.T) {
+	m := &Manager{ttl: time.Hour, jobs: map[string]*Job{}}
+	expiresAt := time.Now().Add(time.Hour)
+	m.jobs["running"] = &Job{ID: "running", UserID: 1, Status: StatusRunning}
+	m.jobs["done"] = &Job{ID: "done", UserID: 2, Status: StatusCompleted, ExpiresAt: &expiresAt}
+
+	// a user with a job in progress gets it back
+	job, err := m.Start(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.ID != "running" || len(m.jobs) != 2 {
+		t.Errorf("got job %s and %d jobs, want the running job back", job.ID, len(m.jobs))
+	}
+
+	for i := len(m.jobs); i < maxJobs; i++ {
+		id := fmt.Sprint(i)
+		m.jobs[id] = &Job{ID: id, UserID: 2, Status: StatusCompleted, ExpiresAt: &expiresAt}
+	}
+	if _, err := m.Start(context.Background(), 3); !errors.Is(err, ErrTooManyJobs) {
+		t.Errorf("got %v with %d jobs kept, want ErrTooManyJobs", err, maxJobs)
+	}
+}
@@ -21,7 +21,15 @@ require (
 	golang.org/x/crypto v0.27.0 // indirect
 	golang.org/x/net v0.29.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
-	golang.org/x/text v0.18.0 // indirect
+	golang.org/x/text v0.20.0 // indirect
 	golang.org/x/time v0.5.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240903143218-8af14fe29dc1 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241118233622-e639e219e697 // indirect
 )
+
+require (
+	contracts v0.0.0
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.24.0
+	google.golang.org/genproto/googleapis/api v0.0.0-20241118233622-e639e219e697
+)
+
+replace contracts => ../contracts
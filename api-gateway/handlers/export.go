@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"api-gateway/exports"
+	"api-gateway/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+)
+
+func (h *Handlers) HandleCreateExport(c echo.Context) error {
+	user := utils.GetUser(c)
+
+	// the job outlives the request, its context keeps the request values
+	// and credentials but isn't canceled along it
+	ctx := metadata.NewOutgoingContext(context.WithoutCancel(c.Request().Context()), utils.OutgoingMetadata(c))
+	job, err := h.Exports.Start(ctx, user.Id)
+	if errors.Is(err, exports.ErrTooManyJobs) {
+		return utils.NewAppError(http.StatusServiceUnavailable, "too many exports in progress, retry later", "")
+	}
+	if err != nil {
+		return utils.NewAppError(http.StatusInternalServerError, "failed to start export", err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+func (h *Handlers) HandleGetExport(c echo.Context) error {
+	user := utils.GetUser(c)
+
+	job, err := h.Exports.Get(c.Param("id"))
+	if err != nil || job.UserID != user.Id {
+		return utils.NewAppError(http.StatusNotFound, "export not found", "")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+func (h *Handlers) HandleDownloadExport(c echo.Context) error {
+	user := utils.GetUser(c)
+
+	job, err := h.Exports.Get(c.Param("id"))
+	if err != nil || job.UserID != user.Id {
+		return utils.NewAppError(http.StatusNotFound, "export not found", "")
+	}
+
+	archive, err := h.Exports.Archive(job.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, exports.ErrJobExpired), errors.Is(err, exports.ErrJobNotFound):
+			return utils.NewAppError(http.StatusGone, "export has expired", err.Error())
+		case errors.Is(err, exports.ErrJobNotReady):
+			return utils.NewAppError(http.StatusConflict, "export is not ready", string(job.Status))
+		default:
+			return utils.NewAppError(http.StatusInternalServerError, "failed to read export", err.Error())
+		}
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"export-%s.zip\"", job.ID))
+	return c.Blob(http.StatusOK, "application/zip", archive)
+}
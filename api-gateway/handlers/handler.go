@@ -1,10 +1,16 @@
 package handlers
 
-import pb "api-gateway/pb/generated"
+import (
+	"api-gateway/exports"
+	"contracts/pb"
+)
 
 type Handlers struct {
 	DateClient    pb.SwipeServiceClient
+	LogClient     pb.LogServiceClient
+	MatchClient   pb.MatchServiceClient
 	PaymentClient pb.SubPaymentClient
 	ProfileClient pb.ProfileServiceClient
 	UserClient    pb.UserServiceClient
+	Exports       *exports.Manager
 }
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"api-gateway/caching"
+	"api-gateway/utils"
+	"context"
+	"contracts/pb"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MeResponse is the aggregated view of the logged in user returned by
+// GET /users/me. Profile and Subscription are null when the user has none.
+type MeResponse struct {
+	User         *pb.User             `json:"user"`
+	Profile      *pb.Profile          `json:"profile"`
+	Subscription *pb.UserSubscription `json:"subscription"`
+}
+
+// SubscriptionResponse is returned by GET /users/me/subscription. Active is
+// null when the user has no paid subscription running.
+type SubscriptionResponse struct {
+	Active        *pb.UserSubscription   `json:"active"`
+	Subscriptions []*pb.UserSubscription `json:"subscriptions"`
+}
+
+// UpdateMeRequest lists what users can change about their own account,
+// premium and verification status are not part of it.
+type UpdateMeRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (h *Handlers) getMyProfile(ctx context.Context, userID uint32) (*pb.Profile, error) {
+	res, err := h.ProfileClient.GetProfileByUserID(ctx, &pb.GetProfileByUserIDRequest{UserId: userID})
+	if err != nil {
+		// not every user has created a profile
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res.Profile, nil
+}
+
+func (h *Handlers) getMySubscriptions(ctx context.Context, userID uint32) ([]*pb.UserSubscription, *pb.UserSubscription, error) {
+	res, err := h.PaymentClient.GetUserSubcriptions(ctx, &pb.GetUserSubcriptionsReq{UserId: int64(userID)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.UserSubscriptions, activeSubscription(res.UserSubscriptions), nil
+}
+
+// activeSubscription returns the paid subscription that runs the longest,
+// or nil.
+func activeSubscription(subs []*pb.UserSubscription) *pb.UserSubscription {
+	now := time.Now()
+
+	var active *pb.UserSubscription
+	for _, sub := range subs {
+		if sub.Payment == nil || sub.Payment.Status != "completed" || sub.EndDate == nil {
+			continue
+		}
+		if sub.EndDate.AsTime().Before(now) {
+			continue
+		}
+		if active == nil || sub.EndDate.AsTime().After(active.EndDate.AsTime()) {
+			active = sub
+		}
+	}
+	return active
+}
+
+func (h *Handlers) HandleGetMe(c echo.Context) error {
+	userID := utils.GetUser(c).Id
+	ctx := utils.CreateContext(c)
+
+	// the three services are independent, ask them at the same time
+	var (
+		wg                           sync.WaitGroup
+		res                          MeResponse
+		userErr, profileErr, subsErr error
+		userRes                      *pb.GetUserResponse
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		userRes, userErr = h.UserClient.GetUser(ctx, &pb.GetUserRequest{Id: userID})
+	}()
+	go func() {
+		defer wg.Done()
+		res.Profile, profileErr = h.getMyProfile(ctx, userID)
+	}()
+	go func() {
+		defer wg.Done()
+		_, res.Subscription, subsErr = h.getMySubscriptions(ctx, userID)
+	}()
+	wg.Wait()
+
+	if userErr != nil {
+		return utils.NewServiceError(userErr)
+	}
+	if profileErr != nil {
+		return utils.NewServiceError(profileErr)
+	}
+	if subsErr != nil {
+		return utils.NewServiceError(subsErr)
+	}
+	res.User = userRes.User
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handlers) HandleUpdateMe(c echo.Context) error {
+	var body UpdateMeRequest
+	err := c.Bind(&body)
+	if err != nil {
+		return utils.NewAppError(http.StatusBadRequest, "invalid request body", err.Error())
+	}
+
+	req := pb.UpdateUserRequest{
+		Id:       utils.GetUser(c).Id,
+		Username: body.Username,
+		Email:    body.Email,
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ctx := utils.CreateContext(c)
+	res, err := h.UserClient.UpdateUser(
+		ctx,
+		&req,
+	)
+	if err != nil {
+		return utils.NewServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handlers) HandleGetMyProfile(c echo.Context) error {
+	ctx := utils.CreateContext(c)
+	profile, err := h.getMyProfile(ctx, utils.GetUser(c).Id)
+	if err != nil {
+		return utils.NewServiceError(err)
+	}
+	if profile == nil {
+		return utils.NewAppError(http.StatusNotFound, "profile not found", "")
+	}
+
+	res := &pb.GetProfileResponse{Profile: profile}
+	if v, ok := caching.ProfileResponseValidators(res); ok {
+		v.Set(c.Response().Header())
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handlers) HandleUpdateMyProfile(c echo.Context) error {
+	var req pb.UpdateProfileRequest
+	err := c.Bind(&req)
+	if err != nil {
+		return utils.NewAppError(http.StatusBadRequest, "invalid request body", err.Error())
+	}
+
+	ctx := utils.CreateContext(c)
+	profile, err := h.getMyProfile(ctx, utils.GetUser(c).Id)
+	if err != nil {
+		return utils.NewServiceError(err)
+	}
+	if profile == nil {
+		return utils.NewAppError(http.StatusNotFound, "profile not found", "")
+	}
+
+	req.Id = profile.Id
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	res, err := h.ProfileClient.UpdateProfile(
+		ctx,
+		&req,
+	)
+	if err != nil {
+		return utils.NewServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handlers) HandleGetMySubscription(c echo.Context) error {
+	ctx := utils.CreateContext(c)
+	subs, active, err := h.getMySubscriptions(ctx, utils.GetUser(c).Id)
+	if err != nil {
+		return utils.NewServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, SubscriptionResponse{
+		Active:        active,
+		Subscriptions: subs,
+	})
+}
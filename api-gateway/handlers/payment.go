@@ -1,9 +1,8 @@
 package handlers
 
 import (
-	pb "api-gateway/pb/generated"
 	"api-gateway/utils"
-	"context"
+	"contracts/pb"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -25,35 +24,17 @@ func (h *Handlers) HandlePaymentCallback(c echo.Context) error {
 	// set token from header
 	req.CallbackToken = verifToken
 
-	res, err := h.PaymentClient.CompletePayment(
-		context.TODO(),
-		&req,
-	)
-	if err != nil {
-		return utils.NewAppError(http.StatusBadRequest, "service error", err.Error())
+	if err := c.Validate(&req); err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusOK, res)
-}
-
-func (h *Handlers) HandleCreateUserSubscription(c echo.Context) error {
-	// pb definitions have json annotations, can use it directly
-	var req pb.CreateUserSubcriptionReq
-	err := c.Bind(&req)
-	if err != nil {
-		return utils.NewAppError(http.StatusBadRequest, "invalid request body", err.Error())
-	}
-
-	ctx := utils.CreateContext(c)
-	// forward
-	req.UserId = 1
-	res, err := h.PaymentClient.CreateUserSubcription(
-		ctx,
+	res, err := h.PaymentClient.CompletePayment(
+		utils.CreateContext(c),
 		&req,
 	)
 	if err != nil {
-		return utils.NewAppError(http.StatusBadRequest, "service error", err.Error())
+		return utils.NewServiceError(err)
 	}
 
-	return c.JSON(http.StatusCreated, res)
+	return c.JSON(http.StatusOK, res)
 }
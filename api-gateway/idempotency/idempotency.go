@@ -0,0 +1,44 @@
+// Package idempotency keeps the responses of requests sent with an
+// Idempotency-Key header, so that retries are answered with the response of
+// the first request instead of running it again. Records are kept in memory
+// or in Redis when the gateway runs several replicas.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Record is what is kept about a key.
+type Record struct {
+	// Fingerprint identifies the request the key was first used with
+	Fingerprint string `json:"fingerprint"`
+	// Done is false while the first request is running
+	Done   bool        `json:"done"`
+	Status int         `json:"status,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// Store keeps the records of keys until they expire.
+type Store interface {
+	// Lock stores a pending record of fingerprint for key, expiring after
+	// ttl, unless key has a record already. It returns the existing record,
+	// or nil when the caller holds the key.
+	Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, error)
+	// Save replaces the record of key with rec, kept for ttl.
+	Save(ctx context.Context, key string, rec Record, ttl time.Duration) error
+	// Unlock drops the record of key, so that the request can be retried.
+	Unlock(ctx context.Context, key string) error
+}
+
+// Fingerprint identifies a request by its method, path and body.
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
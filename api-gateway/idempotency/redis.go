@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"api-gateway/redis"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RedisStore keeps records in Redis, or any server speaking its protocol,
+// so every gateway replica shares them.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+func (s *RedisStore) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, error) {
+	key = "idempotency:" + key
+	pending, err := json.Marshal(Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	// the record may expire between SET and GET, try again then
+	for attempt := 0; attempt < 2; attempt++ {
+		reply, err := s.client.Do(ctx, "SET", key, string(pending), "NX", "PX", milliseconds(ttl))
+		if err != nil {
+			return nil, err
+		}
+		if reply == "OK" {
+			return nil, nil
+		}
+
+		reply, err = s.client.Do(ctx, "GET", key)
+		if err != nil {
+			return nil, err
+		}
+		if reply == nil {
+			continue
+		}
+		value, ok := reply.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected redis reply %v", reply)
+		}
+		var rec Record
+		if err := json.Unmarshal([]byte(value), &rec); err != nil {
+			return nil, err
+		}
+		return &rec, nil
+	}
+	return nil, fmt.Errorf("idempotency key %s expired while locking it", key)
+}
+
+func (s *RedisStore) Save(ctx context.Context, key string, rec Record, ttl time.Duration) error {
+	value, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, "SET", "idempotency:"+key, string(value), "PX", milliseconds(ttl))
+	return err
+}
+
+func (s *RedisStore) Unlock(ctx context.Context, key string) error {
+	_, err := s.client.Do(ctx, "DEL", "idempotency:"+key)
+	return err
+}
+
+func milliseconds(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
@@ -1,12 +1,25 @@
 package main
 
 import (
+	"api-gateway/caching"
 	"api-gateway/clients"
+	"api-gateway/exports"
 	"api-gateway/handlers"
+	"api-gateway/idempotency"
+	"api-gateway/middlewares"
+	"api-gateway/openapi"
+	"api-gateway/ratelimit"
+	"api-gateway/redis"
+	"api-gateway/transcode"
 	"api-gateway/utils"
+	"api-gateway/versioning"
+	"contracts/pb"
+	"expvar"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -21,39 +34,213 @@ func main() {
 		ProfileClient: clients.NewProfileClient(),
 		PaymentClient: clients.NewPaymentClient(),
 		DateClient:    clients.NewDateClient(),
+		MatchClient:   clients.NewMatchClient(),
+		LogClient:     clients.NewLogClient(),
 	}
 
+	// data export archives are kept for EXPORT_TTL, default 24 hours
+	exportTTL := 24 * time.Hour
+	if ttl := os.Getenv("EXPORT_TTL"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			log.Fatalf("invalid EXPORT_TTL: %v", err)
+		}
+		exportTTL = d
+	}
+	handler.Exports = exports.NewManager(&exports.Collector{
+		UserClient:    handler.UserClient,
+		ProfileClient: handler.ProfileClient,
+		DateClient:    handler.DateClient,
+		MatchClient:   handler.MatchClient,
+		PaymentClient: handler.PaymentClient,
+		LogClient:     handler.LogClient,
+	}, exportTTL)
+
+	// rate limit buckets and idempotency records are shared through Redis
+	// when REDIS_URL is set, in memory of this replica otherwise
+	var (
+		limits ratelimit.Store   = ratelimit.NewMemoryStore()
+		keys   idempotency.Store = idempotency.NewMemoryStore()
+	)
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		client, err := redis.Open(redisURL)
+		if err != nil {
+			log.Fatalf("redis: %v", err)
+		}
+		limits = ratelimit.NewRedisStore(client)
+		keys = idempotency.NewRedisStore(client)
+	}
+
+	e, err := newServer(&handler, limits, keys)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// usage metrics, such as the requests per API version, are served apart
+	// from the API on METRICS_ADDR, e.g. "127.0.0.1:9090"
+	if addr := os.Getenv("METRICS_ADDR"); addr != "" {
+		go func() {
+			log.Fatal(http.ListenAndServe(addr, expvar.Handler()))
+		}()
+	}
+
+	//start server
+	log.Fatal(e.Start(fmt.Sprintf(":%s", os.Getenv("PORT"))))
+}
+
+// rpcPolicies tells how the transcoded RPCs are exposed, keyed by full
+// method name. RPCs missing from it require a bearer token.
+var rpcPolicies = map[string]transcode.Policy{
+	pb.UserService_Register_FullMethodName:      {Public: true, Limit: "login", Status: http.StatusCreated},
+	pb.UserService_Login_FullMethodName:         {Public: true, Limit: "login"},
+	pb.UserService_VerifyMFA_FullMethodName:     {Public: true, Limit: "login"},
+	pb.UserService_LoginWithOIDC_FullMethodName: {Public: true, Limit: "login"},
+
+	pb.ProfileService_CreateProfile_FullMethodName: {Status: http.StatusCreated, Caller: []string{"user_id"}},
+	pb.ProfileService_GetProfile_FullMethodName:    {Validators: caching.ProfileResponseValidators},
+
+	pb.SwipeService_RecordSwipe_FullMethodName:     {Limit: "swipes", Status: http.StatusCreated, Caller: []string{"swiper_user_id"}},
+	pb.SwipeService_GetSuggestions_FullMethodName:  {Limit: "swipes", Caller: []string{"user_id"}},
+	pb.SwipeService_GetSwipeHistory_FullMethodName: {Limit: "swipes", Caller: []string{"user_id"}},
+
+	pb.SubPayment_CreateUserSubcription_FullMethodName: {Status: http.StatusCreated, Caller: []string{"user_id"}},
+}
+
+// apiVersions are the generations of the API. The unversioned routes of the
+// first releases are kept for the apps already installed, with the shape of
+// v1. Sunset dates are set with API_SUNSET_<NAME>, e.g. API_SUNSET_V1.
+var apiVersions = []versioning.Version{
+	{Name: "legacy", Deprecated: true, Successor: "/v2"},
+	{Name: "v1", Prefix: "/v1", Successor: "/v2"},
+	{Name: "v2", Prefix: "/v2", Envelope: true},
+}
+
+// newServer registers the middlewares and routes of the gateway. Every
+// route must be documented by apiDocument, see main_test.go.
+func newServer(handler *handlers.Handlers, limitStore ratelimit.Store, keyStore idempotency.Store) (*echo.Echo, error) {
 	e := echo.New()
 
 	e.Use(middleware.Recover())
 	e.Use(middleware.Logger())
 	//set error handler
 	e.HTTPErrorHandler = utils.ErrorHandler
+	// handlers validate requests with c.Validate once they are complete
+	e.Validator = utils.Validator{}
+	// client addresses are read from X-Forwarded-For only when set by a
+	// proxy on a private network, so clients can't dodge rate limits
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
 
-	//user-subs
-	userSubs := e.Group("/subscribe")
-	userSubs.POST("", handler.HandleCreateUserSubscription)
-	//callback for xendit
-	e.POST("/payment-callback", handler.HandlePaymentCallback)
-
-	//user
-	users := e.Group("/users")
-	users.POST("/register", handler.HandleRegister)
-	users.POST("/login", handler.HandleLogin)
-
-	//profile
-	profiles := e.Group("/profiles")
-	profiles.POST("", handler.HandleCreateProfile)
-	profiles.GET("/:id", handler.HandleGetProfile)
-	profiles.PUT("/:id", handler.HandleUpdateProfile)
-	profiles.DELETE("/:id", handler.HandleDeleteProfile)
-
-	//date
-	swipes := e.Group("/swipes")
-	swipes.POST("", handler.HandleRecordSwipe)
-	swipes.GET("", handler.HandleGetSuggestions)
-	swipes.GET("/history", handler.HandleSwipeHistory)
+	limiter := middlewares.NewRateLimiter(limitStore)
 
-	//start server
-	log.Fatal(e.Start(fmt.Sprintf(":%s", os.Getenv("PORT"))))
+	// every policy can be overridden with RATE_LIMIT_<NAME>, e.g.
+	// RATE_LIMIT_LOGIN=10/1m
+	policies := map[string]ratelimit.Policy{}
+	for _, def := range []ratelimit.Policy{
+		{Name: "default", Limit: 300, Window: time.Minute},
+		{Name: "login", Limit: 10, Window: time.Minute},
+		{Name: "swipes", Limit: 120, Window: time.Minute},
+		{Name: "payment_callback", Limit: 60, Window: time.Minute},
+	} {
+		policy, err := ratelimit.FromEnv(def)
+		if err != nil {
+			return nil, err
+		}
+		policies[def.Name] = policy
+	}
+	e.Use(limiter.Limit(policies["default"], middlewares.ByIP))
+	// limits applied on top of the default one, by policy name
+	limits := map[string]echo.MiddlewareFunc{
+		"login":            limiter.Limit(policies["login"], middlewares.ByIP),
+		"swipes":           limiter.Limit(policies["swipes"], middlewares.ByUser),
+		"payment_callback": limiter.Limit(policies["payment_callback"], middlewares.ByIP),
+	}
+
+	// routes declare the access they require, the auth middleware
+	// validates the bearer token once and stores the user for handlers
+	auth := middlewares.NewAuth(handler.UserClient)
+	public := auth.Require(middlewares.Public)
+	authenticated := auth.Require(middlewares.Authenticated)
+
+	// responses to requests with an Idempotency-Key header are kept for
+	// IDEMPOTENCY_TTL, default 24 hours, and replayed to their retries
+	keyTTL := 24 * time.Hour
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid IDEMPOTENCY_TTL %q", ttl)
+		}
+		keyTTL = d
+	}
+	idempotent := middlewares.NewIdempotency(keyStore, keyTTL).Replay(middlewares.ByUser)
+
+	// RPCs with google.api.http options are transcoded by grpc-gateway
+	gateway, err := transcode.New(transcode.Clients{
+		User:    handler.UserClient,
+		Profile: handler.ProfileClient,
+		Swipe:   handler.DateClient,
+		Payment: handler.PaymentClient,
+	}, rpcPolicies)
+	if err != nil {
+		return nil, err
+	}
+	// responses of authenticated routes must not be kept by shared caches,
+	// clients revalidate those with validators before reusing them
+	var (
+		noStore     = middlewares.CacheControl("no-store")
+		revalidate  = middlewares.CacheControl("private, no-cache")
+		publicCache = middlewares.CacheControl("public, max-age=300")
+	)
+
+	// every version serves the same routes, enveloped versions only change
+	// the shape of responses
+	for _, def := range apiVersions {
+		version, err := versioning.FromEnv(def)
+		if err != nil {
+			return nil, err
+		}
+		// responses hold private data unless routes tell otherwise
+		api := e.Group(version.Prefix, version.Middleware(), noStore)
+
+		for _, route := range gateway.Routes() {
+			policy := gateway.Policy(route)
+			chain := []echo.MiddlewareFunc{authenticated}
+			if policy.Public {
+				chain = []echo.MiddlewareFunc{public}
+			}
+			if policy.Limit != "" {
+				limit, ok := limits[policy.Limit]
+				if !ok {
+					return nil, fmt.Errorf("%s: unknown rate limit policy %q", route.FullMethod, policy.Limit)
+				}
+				chain = append(chain, limit)
+			}
+			if policy.Validators != nil {
+				chain = append(chain, revalidate, middlewares.Conditional())
+			}
+			// after the limits, rejected requests aren't kept
+			chain = append(chain, idempotent)
+			api.Add(route.Method, route.Path, gateway.Handler(route), chain...)
+		}
+
+		// routes combining several RPCs or not backed by one are hand-written
+
+		//callback for xendit, verified with the webhook token instead
+		api.POST("/payment-callback", handler.HandlePaymentCallback, public, limits["payment_callback"], idempotent)
+
+		me := api.Group("/users/me", authenticated, idempotent)
+		me.GET("", handler.HandleGetMe)
+		me.PATCH("", handler.HandleUpdateMe)
+		me.GET("/profile", handler.HandleGetMyProfile, revalidate, middlewares.Conditional())
+		me.PUT("/profile", handler.HandleUpdateMyProfile)
+		me.GET("/subscription", handler.HandleGetMySubscription)
+		me.POST("/export", handler.HandleCreateExport)
+		me.GET("/export/:id", handler.HandleGetExport)
+		me.GET("/export/:id/download", handler.HandleDownloadExport)
+
+		//docs
+		api.GET("/openapi.json", openapi.Handler(apiDocument(gateway, version)), public, publicCache)
+		api.GET("/docs", openapi.UIHandler(apiInfo.Title, version.Prefix+"/openapi.json"), public, publicCache)
+	}
+
+	return e, nil
 }
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"api-gateway/handlers"
+	"api-gateway/idempotency"
+	"api-gateway/openapi"
+	"api-gateway/ratelimit"
+	"context"
+	"contracts/pb"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDocumentCoversRoutes(t *testing.T) {
+	e, err := newServer(&handlers.Handlers{}, ratelimit.NewMemoryStore(), idempotency.NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every version documents its own routes
+	paths := map[string]map[string]any{}
+	for _, version := range apiVersions {
+		spec := version.Prefix + "/openapi.json"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, spec, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s answered %d", spec, rec.Code)
+		}
+		var doc struct {
+			Paths map[string]map[string]any `json:"paths"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+		for path, operations := range doc.Paths {
+			paths[path] = operations
+		}
+	}
+
+	registered := map[string]bool{}
+	for _, route := range e.Routes() {
+		// groups register catch-all routes to run their middlewares
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		path := openapi.PathKey(route.Path)
+		registered[route.Method+" "+path] = true
+		if _, ok := paths[path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("%s %s is registered but missing from the document", route.Method, route.Path)
+		}
+	}
+
+	for path, operations := range paths {
+		for method := range operations {
+			if !registered[strings.ToUpper(method)+" "+path] {
+				t.Errorf("%s %s is documented but not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+type fakeUserClient struct {
+	pb.UserServiceClient
+}
+
+func (fakeUserClient) IsValidToken(ctx context.Context, req *pb.IsValidTokenRequest, opts ...grpc.CallOption) (*pb.IsValidTokenResponse, error) {
+	if req.Token != "token" {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return &pb.IsValidTokenResponse{Valid: true, User: &pb.User{Id: 1, Role: "user"}}, nil
+}
+
+type fakeProfileClient struct {
+	pb.ProfileServiceClient
+}
+
+func (fakeProfileClient) GetProfile(ctx context.Context, req *pb.GetProfileRequest, opts ...grpc.CallOption) (*pb.GetProfileResponse, error) {
+	return &pb.GetProfileResponse{Profile: &pb.Profile{Id: req.Id, UserId: 1, Bio: "bio"}}, nil
+}
+
+func TestVersionRoutes(t *testing.T) {
+	e, err := newServer(&handlers.Handlers{
+		UserClient:    fakeUserClient{},
+		ProfileClient: fakeProfileClient{},
+	}, ratelimit.NewMemoryStore(), idempotency.NewMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type profileBody struct {
+		Profile struct {
+			ID  uint32 `json:"id"`
+			Bio string `json:"bio"`
+		} `json:"profile"`
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		token          string
+		wantStatus     int
+		wantDeprecated bool
+		wantLink       string
+		wantEnvelope   bool
+	}{
+		{name: "legacy", path: "/profiles/7", token: "token", wantStatus: http.StatusOK, wantDeprecated: true, wantLink: `</v2/profiles/7>; rel="successor-version"`},
+		{name: "v1", path: "/v1/profiles/7", token: "token", wantStatus: http.StatusOK},
+		{name: "v2", path: "/v2/profiles/7", token: "token", wantStatus: http.StatusOK, wantEnvelope: true},
+		{name: "v1 error", path: "/v1/profiles/7", wantStatus: http.StatusUnauthorized},
+		{name: "v2 error", path: "/v2/profiles/7", wantStatus: http.StatusUnauthorized, wantEnvelope: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if deprecated := rec.Header().Get("Deprecation") == "true"; deprecated != tt.wantDeprecated {
+				t.Errorf("deprecated: got %v, want %v", deprecated, tt.wantDeprecated)
+			}
+			if got := rec.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("got Link %s, want %s", got, tt.wantLink)
+			}
+
+			body := rec.Body.Bytes()
+			if tt.wantEnvelope {
+				var env struct {
+					Data   json.RawMessage   `json:"data"`
+					Meta   map[string]any    `json:"meta"`
+					Errors []json.RawMessage `json:"errors"`
+				}
+				if err := json.Unmarshal(body, &env); err != nil {
+					t.Fatal(err)
+				}
+				if env.Meta["version"] != "v2" || env.Meta["status"] != float64(tt.wantStatus) {
+					t.Errorf("got meta %v", env.Meta)
+				}
+				if tt.wantStatus >= http.StatusBadRequest {
+					if string(env.Data) != "null" || len(env.Errors) != 1 || !strings.Contains(string(env.Errors[0]), `"UNAUTHENTICATED"`) {
+						t.Errorf("got envelope %s, want the error in errors", body)
+					}
+					return
+				}
+				if len(env.Errors) != 0 {
+					t.Errorf("got errors %s on a success", body)
+				}
+				body = env.Data
+			}
+			if tt.wantStatus >= http.StatusBadRequest {
+				if !strings.HasPrefix(string(body), `{"code":"UNAUTHENTICATED"`) {
+					t.Errorf("got body %s, want a bare error", body)
+				}
+				return
+			}
+
+			var got profileBody
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Profile.ID != 7 || got.Profile.Bio != "bio" {
+				t.Errorf("got body %s, want profile 7", body)
+			}
+		})
+	}
+}
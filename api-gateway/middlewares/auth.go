@@ -0,0 +1,62 @@
+package middlewares
+
+import (
+	"api-gateway/utils"
+	"contracts/pb"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access is the level of access a route group requires.
+type Access int
+
+const (
+	// Public routes can be called without a token.
+	Public Access = iota
+	// Authenticated routes require a valid bearer token.
+	Authenticated
+)
+
+// Auth validates bearer tokens against users-service once per request and
+// stores the verified user in the echo.Context for handlers to use.
+type Auth struct {
+	userClient pb.UserServiceClient
+}
+
+func NewAuth(userClient pb.UserServiceClient) *Auth {
+	return &Auth{
+		userClient: userClient,
+	}
+}
+
+// Require returns a middleware enforcing the given access level.
+func (a *Auth) Require(access Access) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if access == Public {
+				return next(c)
+			}
+
+			token := utils.ExtractAuthToken(c)
+			if token == "" {
+				return utils.NewAppError(http.StatusUnauthorized, "missing bearer token", "")
+			}
+
+			res, err := a.userClient.IsValidToken(c.Request().Context(), &pb.IsValidTokenRequest{Token: token})
+			if err != nil && status.Code(err) != codes.Unauthenticated {
+				// users-service being unavailable or failing isn't the
+				// caller's fault
+				return utils.NewServiceError(err)
+			}
+			if err != nil || !res.Valid {
+				return utils.NewAppError(http.StatusUnauthorized, "invalid token", "the bearer token is invalid or expired")
+			}
+
+			utils.SetUser(c, res.User)
+			return next(c)
+		}
+	}
+}
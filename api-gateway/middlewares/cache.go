@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"api-gateway/caching"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CacheControl sets the Cache-Control header of responses to value. Route
+// middlewares run after group ones, so routes can override the value of
+// their group.
+func CacheControl(value string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderCacheControl, value)
+			return next(c)
+		}
+	}
+}
+
+// Conditional answers 304 Not Modified, without a body, to the GET
+// requests whose If-None-Match or If-Modified-Since headers match the
+// validators set by the handler, see caching.Validators.
+func Conditional() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+
+			res := c.Response()
+			w := &conditionalWriter{ResponseWriter: res.Writer, req: req}
+			res.Writer = w
+			defer func() { res.Writer = w.ResponseWriter }()
+
+			err := next(c)
+			if w.notModified {
+				res.Status = http.StatusNotModified
+			}
+			return err
+		}
+	}
+}
+
+// conditionalWriter turns successful responses into 304 Not Modified ones
+// when the client holds them already, and drops their body.
+type conditionalWriter struct {
+	http.ResponseWriter
+	req         *http.Request
+	notModified bool
+}
+
+func (w *conditionalWriter) WriteHeader(status int) {
+	if status == http.StatusOK && caching.NotModified(w.req, w.Header()) {
+		w.notModified = true
+		header := w.Header()
+		header.Del(echo.HeaderContentType)
+		header.Del(echo.HeaderContentLength)
+		status = http.StatusNotModified
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *conditionalWriter) Write(b []byte) (int, error) {
+	if w.notModified {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"api-gateway/caching"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestConditional(t *testing.T) {
+	const etag = `W/"profile-7-1"`
+	e := echo.New()
+	profile := func(c echo.Context) error {
+		caching.Validators{ETag: etag}.Set(c.Response().Header())
+		return c.JSON(http.StatusOK, map[string]int{"id": 7})
+	}
+	missing := func(c echo.Context) error {
+		caching.Validators{ETag: etag}.Set(c.Response().Header())
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+	e.GET("/profile", profile, CacheControl("private, no-cache"), Conditional())
+	e.PUT("/profile", profile, Conditional())
+	e.GET("/missing", missing, Conditional())
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		ifNoneMatch string
+		wantStatus  int
+		wantBody    bool
+	}{
+		{name: "unconditional", method: http.MethodGet, path: "/profile", wantStatus: http.StatusOK, wantBody: true},
+		{name: "held by the client", method: http.MethodGet, path: "/profile", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "changed since", method: http.MethodGet, path: "/profile", ifNoneMatch: `W/"profile-7-0"`, wantStatus: http.StatusOK, wantBody: true},
+		{name: "not a read", method: http.MethodPut, path: "/profile", ifNoneMatch: etag, wantStatus: http.StatusOK, wantBody: true},
+		{name: "error", method: http.MethodGet, path: "/missing", ifNoneMatch: etag, wantStatus: http.StatusNotFound, wantBody: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("ETag"); got != etag {
+				t.Errorf("got ETag %s, want %s", got, etag)
+			}
+			if hasBody := rec.Body.Len() > 0; hasBody != tt.wantBody {
+				t.Errorf("got body %q, want body %v", rec.Body.String(), tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusNotModified {
+				if got := rec.Header().Get(echo.HeaderContentType); got != "" {
+					t.Errorf("got Content-Type %s on a 304", got)
+				}
+				if got := rec.Header().Get(echo.HeaderCacheControl); got != "private, no-cache" {
+					t.Errorf("got Cache-Control %q, want it kept on a 304", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package middlewares
+
+import (
+	"api-gateway/idempotency"
+	"api-gateway/utils"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on responses replayed from a record
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	// a key stays locked for lockTTL at most while its first request runs,
+	// in case the replica serving it stops before saving the response
+	lockTTL = time.Minute
+	// storeTimeout bounds saving the response once the request is done
+	storeTimeout = 5 * time.Second
+)
+
+// storedHeaders are the headers replayed along stored responses.
+var storedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation}
+
+// Idempotency answers the retries of a mutating request sent with the same
+// Idempotency-Key header with the response of the first request.
+type Idempotency struct {
+	store idempotency.Store
+	ttl   time.Duration
+}
+
+// NewIdempotency keeps responses in store for ttl.
+func NewIdempotency(store idempotency.Store, ttl time.Duration) *Idempotency {
+	return &Idempotency{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Replay returns a middleware handling the Idempotency-Key header of POST
+// and PATCH requests, keys are scoped by the key of scope so clients can't
+// read the responses of each other. A key reused with another request is
+// rejected, as well as a retry sent while the first request is running.
+// Server errors and rate limited requests aren't kept, they can be retried.
+// Requests are let through when the store fails, an outage of the store
+// shouldn't take the gateway down.
+func (i *Idempotency) Replay(scope KeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if key == "" || req.Method != http.MethodPost && req.Method != http.MethodPatch {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				appErr := utils.NewAppError(http.StatusBadRequest, "invalid Idempotency-Key header", "keys are 255 characters at most")
+				appErr.Reason = "INVALID_IDEMPOTENCY_KEY"
+				return appErr
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return utils.NewAppError(http.StatusBadRequest, "invalid request body", err.Error())
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			key = scope(c) + ":" + key
+			fingerprint := idempotency.Fingerprint(req.Method, req.URL.Path, body)
+			rec, err := i.store.Lock(ctx, key, fingerprint, lockTTL)
+			if err != nil {
+				log.Printf("idempotency: %s", err.Error())
+				return next(c)
+			}
+			if rec != nil {
+				return replay(c, rec, fingerprint)
+			}
+
+			// the response goes to the client and is kept for retries, errors
+			// are answered here to keep them too
+			res := c.Response()
+			w := &teeWriter{ResponseWriter: res.Writer}
+			res.Writer = w
+			defer func() { res.Writer = w.ResponseWriter }()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			// the client hanging up must not leave the key locked until lockTTL
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+			defer cancel()
+			if w.status == 0 || w.status >= http.StatusInternalServerError || w.status == http.StatusTooManyRequests {
+				err = i.store.Unlock(ctx, key)
+			} else {
+				err = i.store.Save(ctx, key, idempotency.Record{
+					Fingerprint: fingerprint,
+					Done:        true,
+					Status:      w.status,
+					Header:      storedHeader(res.Header()),
+					Body:        w.body.Bytes(),
+				}, i.ttl)
+			}
+			if err != nil {
+				log.Printf("idempotency: %s", err.Error())
+			}
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, rec *idempotency.Record, fingerprint string) error {
+	if rec.Fingerprint != fingerprint {
+		appErr := utils.NewAppError(http.StatusUnprocessableEntity, "Idempotency-Key already used with another request", "")
+		appErr.Reason = "IDEMPOTENCY_KEY_REUSED"
+		return appErr
+	}
+	if !rec.Done {
+		appErr := utils.NewAppError(http.StatusConflict, "a request with this Idempotency-Key is still running", "")
+		appErr.Reason = "IDEMPOTENCY_KEY_IN_USE"
+		return appErr
+	}
+
+	header := c.Response().Header()
+	for name, values := range rec.Header {
+		header[name] = values
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+	c.Response().WriteHeader(rec.Status)
+	_, err := c.Response().Write(rec.Body)
+	return err
+}
+
+func storedHeader(header http.Header) http.Header {
+	stored := http.Header{}
+	for _, name := range storedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			stored[name] = values
+		}
+	}
+	return stored
+}
+
+// teeWriter keeps a copy of the response it writes.
+type teeWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *teeWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"api-gateway/idempotency"
+	"api-gateway/utils"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestIdempotencyReplay(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	e := echo.New()
+	e.HTTPErrorHandler = utils.ErrorHandler
+
+	calls := 0
+	failing := false
+	scope := func(c echo.Context) string { return "user:1" }
+	e.POST("/swipes", func(c echo.Context) error {
+		calls++
+		if failing {
+			return utils.NewAppError(http.StatusServiceUnavailable, "service unavailable", "")
+		}
+		return c.JSON(http.StatusCreated, map[string]int{"call": calls})
+	}, NewIdempotency(store, time.Hour).Replay(scope))
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/swipes", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// a request holding its key while it runs
+	running := idempotency.Fingerprint(http.MethodPost, "/swipes", []byte(`{"liked":false}`))
+	if _, err := store.Lock(context.Background(), "user:1:running", running, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		key          string
+		body         string
+		failing      bool
+		wantStatus   int
+		wantCalls    int
+		wantReplayed bool
+		wantBody     string
+	}{
+		{name: "first request", key: "first", body: `{"liked":true}`, wantStatus: http.StatusCreated, wantCalls: 1},
+		{name: "retry", key: "first", body: `{"liked":true}`, wantStatus: http.StatusCreated, wantCalls: 1, wantReplayed: true, wantBody: `{"call":1}`},
+		{name: "key reused with another body", key: "first", body: `{"liked":false}`, wantStatus: http.StatusUnprocessableEntity, wantCalls: 1, wantBody: "IDEMPOTENCY_KEY_REUSED"},
+		{name: "retry while running", key: "running", body: `{"liked":false}`, wantStatus: http.StatusConflict, wantCalls: 1, wantBody: "IDEMPOTENCY_KEY_IN_USE"},
+		{name: "without key", body: `{"liked":true}`, wantStatus: http.StatusCreated, wantCalls: 2},
+		{name: "server error", key: "failing", body: `{"liked":true}`, failing: true, wantStatus: http.StatusServiceUnavailable, wantCalls: 3},
+		{name: "retry after a server error", key: "failing", body: `{"liked":true}`, wantStatus: http.StatusCreated, wantCalls: 4},
+		{name: "key too long", key: strings.Repeat("k", maxIdempotencyKeyLength+1), body: `{"liked":true}`, wantStatus: http.StatusBadRequest, wantCalls: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing = tt.failing
+			rec := send(tt.key, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("the handler ran %d times, want %d", calls, tt.wantCalls)
+			}
+			if replayed := rec.Header().Get(HeaderIdempotentReplayed) == "true"; replayed != tt.wantReplayed {
+				t.Errorf("replayed: got %v, want %v", replayed, tt.wantReplayed)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("got body %s, want it to contain %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
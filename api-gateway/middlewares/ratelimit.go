@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"api-gateway/ratelimit"
+	"api-gateway/utils"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// KeyFunc returns the key of the bucket a request takes a token from.
+type KeyFunc func(c echo.Context) string
+
+// ByIP limits requests per client address.
+func ByIP(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// ByUser limits requests per authenticated user, it must run after the auth
+// middleware. Requests without a user are limited per client address.
+func ByUser(c echo.Context) string {
+	if user := utils.GetUser(c); user != nil {
+		return fmt.Sprintf("user:%d", user.Id)
+	}
+	return ByIP(c)
+}
+
+// RateLimiter limits requests with token buckets kept in a ratelimit.Store.
+type RateLimiter struct {
+	store ratelimit.Store
+}
+
+func NewRateLimiter(store ratelimit.Store) *RateLimiter {
+	return &RateLimiter{
+		store: store,
+	}
+}
+
+// Limit returns a middleware allowing the requests of each key according to
+// policy. Every response carries the RateLimit-* headers, rejected ones a
+// Retry-After header too. Requests are let through when the store fails,
+// an outage of the store shouldn't take the gateway down.
+func (rl *RateLimiter) Limit(policy ratelimit.Policy, key KeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res, err := rl.store.Take(c.Request().Context(), policy, key(c))
+			if err != nil {
+				log.Printf("rate limit %s: %s", policy.Name, err.Error())
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(policy.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, ceilSeconds(policy.Window)))
+
+			if !res.Allowed {
+				retryAfter := ceilSeconds(res.RetryAfter)
+				header.Set("Retry-After", strconv.Itoa(retryAfter))
+
+				appErr := utils.NewAppError(http.StatusTooManyRequests, "too many requests", fmt.Sprintf("retry in %d seconds", retryAfter))
+				appErr.Reason = "RATE_LIMITED"
+				return appErr
+			}
+			return next(c)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
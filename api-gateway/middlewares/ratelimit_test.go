@@ -0,0 +1,95 @@
+package middlewares
+
+import (
+	"api-gateway/ratelimit"
+	"api-gateway/utils"
+	"contracts/pb"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRateLimiter(t *testing.T) {
+	policy := ratelimit.Policy{Name: "test", Limit: 2, Window: time.Minute}
+	e := echo.New()
+	e.HTTPErrorHandler = utils.ErrorHandler
+
+	// stands for the auth middleware
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id := c.Request().Header.Get("X-Test-User"); id != "" {
+				userID, _ := strconv.Atoi(id)
+				utils.SetUser(c, &pb.User{Id: uint32(userID)})
+			}
+			return next(c)
+		}
+	}
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	rl := NewRateLimiter(ratelimit.NewMemoryStore())
+	e.GET("/by-ip", ok, rl.Limit(policy, ByIP))
+	e.GET("/by-user", ok, authenticate, rl.Limit(policy, ByUser))
+
+	tests := []struct {
+		name          string
+		path          string
+		ip            string
+		user          string
+		wantStatus    int
+		wantRemaining string
+	}{
+		{name: "first request of an address", path: "/by-ip", ip: "10.0.0.1", wantStatus: http.StatusNoContent, wantRemaining: "1"},
+		{name: "second request of an address", path: "/by-ip", ip: "10.0.0.1", wantStatus: http.StatusNoContent, wantRemaining: "0"},
+		{name: "third request of an address", path: "/by-ip", ip: "10.0.0.1", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+		{name: "another address", path: "/by-ip", ip: "10.0.0.2", wantStatus: http.StatusNoContent, wantRemaining: "1"},
+		{name: "user", path: "/by-user", ip: "10.0.0.3", user: "1", wantStatus: http.StatusNoContent, wantRemaining: "1"},
+		{name: "same user from another address", path: "/by-user", ip: "10.0.0.4", user: "1", wantStatus: http.StatusNoContent, wantRemaining: "0"},
+		{name: "same user again", path: "/by-user", ip: "10.0.0.5", user: "1", wantStatus: http.StatusTooManyRequests, wantRemaining: "0"},
+		{name: "another user from the same address", path: "/by-user", ip: "10.0.0.5", user: "2", wantStatus: http.StatusNoContent, wantRemaining: "1"},
+		{name: "anonymous request limited by address", path: "/by-user", ip: "10.0.0.3", wantStatus: http.StatusNoContent, wantRemaining: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = tt.ip + ":1234"
+			if tt.user != "" {
+				req.Header.Set("X-Test-User", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			header := rec.Header()
+			if got := header.Get("RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Errorf("got RateLimit-Remaining %s, want %s", got, tt.wantRemaining)
+			}
+			if got := header.Get("RateLimit-Limit"); got != "2" {
+				t.Errorf("got RateLimit-Limit %s, want 2", got)
+			}
+			if got := header.Get("RateLimit-Policy"); got != "2;w=60" {
+				t.Errorf("got RateLimit-Policy %s, want 2;w=60", got)
+			}
+			if reset, err := strconv.Atoi(header.Get("RateLimit-Reset")); err != nil || reset <= 0 || reset > 60 {
+				t.Errorf("got RateLimit-Reset %s, want up to the window", header.Get("RateLimit-Reset"))
+			}
+
+			retryAfter := header.Get("Retry-After")
+			if tt.wantStatus != http.StatusTooManyRequests {
+				if retryAfter != "" {
+					t.Errorf("got Retry-After %s on an allowed request", retryAfter)
+				}
+				return
+			}
+			// a token refills every 30s
+			if seconds, err := strconv.Atoi(retryAfter); err != nil || seconds <= 0 || seconds > 30 {
+				t.Errorf("got Retry-After %s, want up to 30 seconds", retryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEOpenAPIJSON is the media type of OpenAPI documents encoded as JSON.
+// Unlike application/json, their bodies are never wrapped in an envelope.
+const MIMEOpenAPIJSON = "application/vnd.oai.openapi+json;version=3.0"
+
+// Handler serves doc as JSON. It is encoded once, the document doesn't
+// change while the gateway runs.
+func Handler(doc map[string]any) echo.HandlerFunc {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		panic(fmt.Sprintf("openapi: encoding document: %v", err))
+	}
+	return func(c echo.Context) error {
+		return c.Blob(http.StatusOK, MIMEOpenAPIJSON, body)
+	}
+}
+
+// swaggerUI loads Swagger UI from a CDN, the gateway only serves this page.
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>%[1]s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: %[2]q, dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>`
+
+// UIHandler serves a Swagger UI page browsing the document at specURL.
+func UIHandler(title, specURL string) echo.HandlerFunc {
+	page := fmt.Sprintf(swaggerUI, title, specURL)
+	return func(c echo.Context) error {
+		return c.HTML(http.StatusOK, page)
+	}
+}
@@ -0,0 +1,258 @@
+// Package openapi builds the OpenAPI 3 document of the gateway from the
+// operations it registers, with schemas derived from the proto messages
+// and Go structs they exchange.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Operation documents a route of the gateway.
+type Operation struct {
+	Method string
+	// Path is the echo path, e.g. "/profiles/:id"
+	Path    string
+	Tag     string
+	Summary string
+	// Public operations can be called without a bearer token
+	Public bool
+	Query  []Param
+	Header []Param
+	// Request is the JSON body, a proto message or a Go struct, nil for none
+	Request any
+	// Omit lists the fields of Request filled in by the gateway, such as the
+	// authenticated user or path parameters, rather than by clients
+	Omit []string
+	// Status is the status of successful responses, 200 by default
+	Status int
+	// Response is the JSON body of successful responses, nil for none
+	Response any
+	// ContentType of successful responses, application/json by default
+	ContentType string
+	// Conditional operations answer with ETag and Last-Modified headers,
+	// and 304 to requests for a representation clients hold already
+	Conditional bool
+}
+
+// Param is a query or header parameter.
+type Param struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+	// BasePath prefixes every path, e.g. "/v2"
+	BasePath string
+	// Meta, when set, documents JSON bodies wrapped in an envelope,
+	// {"data": body, "meta": Meta, "errors": []} on success and
+	// {"data": null, "meta": Meta, "errors": [error]} on failure
+	Meta any
+}
+
+var pathParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// PathKey converts an echo path to an OpenAPI one, "/profiles/:id" becomes
+// "/profiles/{id}".
+func PathKey(path string) string {
+	return pathParam.ReplaceAllString(path, "{$1}")
+}
+
+// Document returns the OpenAPI 3 document of ops, ready to be encoded as
+// JSON. Failed operations answer with errorBody.
+func Document(info Info, ops []Operation, errorBody any) map[string]any {
+	s := newSchemas()
+	if info.Meta != nil {
+		s.meta = s.of(info.Meta)
+	}
+	errorRef := s.of(errorBody)
+
+	paths := map[string]map[string]any{}
+	for _, op := range ops {
+		key := info.BasePath + PathKey(op.Path)
+		if paths[key] == nil {
+			paths[key] = map[string]any{}
+		}
+		paths[key][strings.ToLower(op.Method)] = s.operation(op, errorRef)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       info.Title,
+			"version":     info.Version,
+			"description": info.Description,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": s.components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+	}
+}
+
+func (s *schemas) operation(op Operation, errorRef map[string]any) map[string]any {
+	operation := map[string]any{
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+	}
+	if op.Tag != "" {
+		operation["tags"] = []string{op.Tag}
+	}
+	if op.Public {
+		operation["security"] = []any{}
+	}
+
+	parameters := []any{}
+	for _, match := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+		parameters = append(parameters, map[string]any{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		})
+	}
+	for _, p := range op.Query {
+		param := map[string]any{
+			"name":   p.Name,
+			"in":     "query",
+			"schema": map[string]any{"type": p.Type},
+		}
+		if p.Description != "" {
+			param["description"] = p.Description
+		}
+		parameters = append(parameters, param)
+	}
+	headers := op.Header
+	if op.Conditional {
+		headers = append(headers,
+			Param{Name: "If-None-Match", Type: "string", Description: "ETag of the representation held by the client"},
+			Param{Name: "If-Modified-Since", Type: "string", Description: "Last-Modified date of the representation held by the client"},
+		)
+	}
+	for _, p := range headers {
+		param := map[string]any{
+			"name":   p.Name,
+			"in":     "header",
+			"schema": map[string]any{"type": p.Type},
+		}
+		if p.Description != "" {
+			param["description"] = p.Description
+		}
+		parameters = append(parameters, param)
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if op.Request != nil {
+		schema := s.of(op.Request)
+		if len(op.Omit) > 0 {
+			schema = s.without(op.Request, op.Omit)
+		}
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": schema},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	if op.Response != nil || op.ContentType != "" {
+		contentType := op.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		schema := map[string]any{"type": "string", "format": "binary"}
+		if op.Response != nil {
+			schema = s.of(op.Response)
+		}
+		success["content"] = map[string]any{
+			contentType: map[string]any{"schema": schema},
+		}
+	}
+
+	responses := map[string]any{
+		strconv.Itoa(status): success,
+	}
+	if op.Conditional {
+		success["headers"] = map[string]any{
+			"ETag":          map[string]any{"schema": map[string]any{"type": "string"}},
+			"Last-Modified": map[string]any{"schema": map[string]any{"type": "string"}},
+		}
+		responses["304"] = map[string]any{"description": "Not modified since the representation held by the client"}
+	}
+
+	errorContent := map[string]any{
+		"application/json": map[string]any{"schema": s.envelope(nil, errorRef)},
+	}
+	responses["429"] = map[string]any{
+		"description": "Too many requests, retry after the Retry-After header",
+		"content":     errorContent,
+	}
+	responses["default"] = map[string]any{"description": "Error", "content": errorContent}
+	operation["responses"] = responses
+	return operation
+}
+
+// envelope wraps the schema of a body in the envelope of the document, if
+// any. Successful bodies are given as data, failed ones as errorRef.
+func (s *schemas) envelope(data, errorRef map[string]any) map[string]any {
+	if s.meta == nil {
+		if data != nil {
+			return data
+		}
+		return errorRef
+	}
+
+	errors := map[string]any{"type": "array", "maxItems": 0}
+	if data == nil {
+		data = map[string]any{"nullable": true}
+		errors = map[string]any{"type": "array", "items": errorRef}
+	}
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"data", "meta", "errors"},
+		"properties": map[string]any{
+			"data":   data,
+			"meta":   s.meta,
+			"errors": errors,
+		},
+	}
+}
+
+// operationID derives a unique id such as "postUsersLogin" from the method
+// and path of op.
+func operationID(op Operation) string {
+	id := strings.ToLower(op.Method)
+	for _, part := range strings.FieldsFunc(op.Path, func(r rune) bool { return r == '/' || r == '-' }) {
+		if strings.HasPrefix(part, ":") {
+			part = "by_" + part[1:]
+		}
+		for _, word := range strings.Split(part, "_") {
+			if word != "" {
+				id += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return id
+}
@@ -0,0 +1,258 @@
+package openapi
+
+import (
+	"contracts/validate"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	protoMessage = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// schemas collects the components of the document. Proto messages are
+// named after their full name, e.g. "profile.Profile", Go structs after
+// their package and type, e.g. "handlers.MeResponse".
+type schemas struct {
+	components map[string]any
+	// meta is the reference to the meta of envelopes, nil when bodies
+	// aren't wrapped
+	meta map[string]any
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]any{}}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// of returns a reference to the schema of v, a proto message or a Go value.
+func (s *schemas) of(v any) map[string]any {
+	if m, ok := v.(proto.Message); ok {
+		return s.message(m.ProtoReflect().Descriptor())
+	}
+	return s.goType(reflect.TypeOf(v))
+}
+
+// without returns the schema of v, inlined, with the fields in omit left out.
+func (s *schemas) without(v any, omit []string) map[string]any {
+	var schema map[string]any
+	if m, ok := v.(proto.Message); ok {
+		schema = s.messageSchema(m.ProtoReflect().Descriptor())
+	} else {
+		schema = s.structSchema(indirect(reflect.TypeOf(v)))
+	}
+
+	properties := schema["properties"].(map[string]any)
+	for _, name := range omit {
+		delete(properties, name)
+	}
+	if required, ok := schema["required"].([]string); ok {
+		kept := []string{}
+		for _, name := range required {
+			if _, ok := properties[name]; ok {
+				kept = append(kept, name)
+			}
+		}
+		if len(kept) > 0 {
+			schema["required"] = kept
+		} else {
+			delete(schema, "required")
+		}
+	}
+	return schema
+}
+
+func (s *schemas) message(md protoreflect.MessageDescriptor) map[string]any {
+	name := string(md.FullName())
+	if _, ok := s.components[name]; !ok {
+		// registered before its fields so recursive messages terminate
+		s.components[name] = map[string]any{}
+		s.components[name] = s.messageSchema(md)
+	}
+	return ref(name)
+}
+
+// messageSchema follows encoding/json, used by the gateway to answer, with
+// fields named after their proto name and the validate.rules of each field
+// as constraints.
+func (s *schemas) messageSchema(md protoreflect.MessageDescriptor) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		rules := validate.Rules(fd)
+		if rules.GetRequired() {
+			required = append(required, string(fd.Name()))
+		}
+
+		switch {
+		case fd.IsMap():
+			properties[string(fd.Name())] = map[string]any{
+				"type":                 "object",
+				"additionalProperties": s.field(fd.MapValue()),
+			}
+		case fd.IsList():
+			array := map[string]any{"type": "array", "items": s.field(fd)}
+			if rules.MinItems != nil {
+				array["minItems"] = rules.GetMinItems()
+			}
+			if rules.MaxItems != nil {
+				array["maxItems"] = rules.GetMaxItems()
+			}
+			properties[string(fd.Name())] = array
+		default:
+			properties[string(fd.Name())] = s.field(fd)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// field returns the schema of a single value of fd.
+func (s *schemas) field(fd protoreflect.FieldDescriptor) map[string]any {
+	var schema map[string]any
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return s.message(fd.Message())
+	case protoreflect.BoolKind:
+		schema = map[string]any{"type": "boolean"}
+	case protoreflect.StringKind:
+		schema = map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		schema = map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind, protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int32", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		schema = map[string]any{"type": "integer", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		schema = map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.FloatKind:
+		schema = map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		schema = map[string]any{"type": "number", "format": "double"}
+	}
+
+	rules := validate.Rules(fd)
+	if rules.Min != nil {
+		schema["minimum"] = rules.GetMin()
+	}
+	if rules.Max != nil {
+		schema["maximum"] = rules.GetMax()
+	}
+	if rules.MinLen != nil {
+		schema["minLength"] = rules.GetMinLen()
+	}
+	if rules.MaxLen != nil {
+		schema["maxLength"] = rules.GetMaxLen()
+	}
+	if in := rules.GetIn(); len(in) > 0 {
+		schema["enum"] = in
+	}
+	if pattern := rules.GetPattern(); pattern != "" {
+		schema["pattern"] = pattern
+	}
+	if rules.GetEmail() {
+		schema["format"] = "email"
+	}
+	if rules.GetUrl() {
+		schema["format"] = "uri"
+	}
+	return schema
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// goType returns the schema of t as encoded by encoding/json, structs are
+// registered as components.
+func (s *schemas) goType(t reflect.Type) map[string]any {
+	if t.Implements(protoMessage) {
+		return s.message(reflect.Zero(t).Interface().(proto.Message).ProtoReflect().Descriptor())
+	}
+
+	t = indirect(t)
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct:
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := s.components[name]; !ok {
+			s.components[name] = map[string]any{}
+			s.components[name] = s.structSchema(t)
+		}
+		return ref(name)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return map[string]any{"type": "string", "format": "byte"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return map[string]any{"type": "array", "items": s.goType(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.goType(t.Elem())}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{}
+}
+
+// structSchema lists the exported fields of t under their json name, those
+// without omitempty are required.
+func (s *schemas) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		schema := s.goType(f.Type)
+		if f.Type.Kind() == reflect.Pointer {
+			// references can't carry siblings in OpenAPI 3.0
+			schema = map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		properties[name] = schema
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,102 @@
+// Package ratelimit implements token buckets shared by the rate limiting
+// middleware, kept in memory or in Redis when the gateway runs several
+// replicas.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy allows Limit requests per Window. Buckets hold up to Limit tokens
+// and refill continuously, so a client can burst Limit requests and then
+// make one every Window/Limit.
+type Policy struct {
+	// Name identifies the policy in storage keys and in the
+	// RATE_LIMIT_<NAME> environment variable
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// rate is the number of tokens refilled per second.
+func (p Policy) rate() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+func (p Policy) String() string {
+	return fmt.Sprintf("%d/%s", p.Limit, p.Window)
+}
+
+// ParsePolicy parses a policy written as "<limit>/<window>", e.g. "10/1m".
+func ParsePolicy(name, s string) (Policy, error) {
+	limit, window, ok := strings.Cut(s, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid rate limit %q, want <limit>/<window>", s)
+	}
+
+	p := Policy{Name: name}
+	var err error
+	p.Limit, err = strconv.Atoi(limit)
+	if err != nil || p.Limit <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q, limit must be a positive integer", s)
+	}
+	p.Window, err = time.ParseDuration(window)
+	if err != nil || p.Window <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q, window must be a positive duration", s)
+	}
+	return p, nil
+}
+
+// FromEnv returns the policy set in RATE_LIMIT_<NAME>, or def.
+func FromEnv(def Policy) (Policy, error) {
+	env := "RATE_LIMIT_" + strings.ToUpper(def.Name)
+	s := os.Getenv(env)
+	if s == "" {
+		return def, nil
+	}
+
+	p, err := ParsePolicy(def.Name, s)
+	if err != nil {
+		return Policy{}, fmt.Errorf("%s: %w", env, err)
+	}
+	return p, nil
+}
+
+// Result is the state of a bucket after taking a token from it.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the time until the next token, zero when allowed
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+}
+
+// Store keeps the buckets of every policy, keyed by policy name and key.
+type Store interface {
+	// Take takes a token from the bucket of key.
+	Take(ctx context.Context, p Policy, key string) (Result, error)
+}
+
+// result computes the Result of a bucket left with tokens.
+func result(p Policy, allowed bool, tokens float64) Result {
+	r := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(p.Limit) - tokens) / p.rate()),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / p.rate())
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
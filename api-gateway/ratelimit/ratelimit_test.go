@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Policy
+		wantErr bool
+	}{
+		{s: "10/1m", want: Policy{Name: "test", Limit: 10, Window: time.Minute}},
+		{s: "5/30s", want: Policy{Name: "test", Limit: 5, Window: 30 * time.Second}},
+		{s: "10", wantErr: true},
+		{s: "0/1m", wantErr: true},
+		{s: "-1/1m", wantErr: true},
+		{s: "ten/1m", wantErr: true},
+		{s: "10/0s", wantErr: true},
+		{s: "10/minute", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePolicy("test", tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%q: got %v, %v, want %v, error %v", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMemoryStoreBurstAndRefill(t *testing.T) {
+	p := Policy{Name: "test", Limit: 3, Window: 3 * time.Second}
+
+	tests := []struct {
+		name string
+		// elapsed moves the bucket back in time before taking a token
+		elapsed       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{name: "burst 1", wantAllowed: true, wantRemaining: 2},
+		{name: "burst 2", wantAllowed: true, wantRemaining: 1},
+		{name: "burst 3", wantAllowed: true, wantRemaining: 0},
+		{name: "empty", wantAllowed: false, wantRemaining: 0},
+		{name: "refilled one token", elapsed: time.Second, wantAllowed: true, wantRemaining: 0},
+		{name: "half a token", elapsed: 500 * time.Millisecond, wantAllowed: false, wantRemaining: 0},
+		{name: "refilled past the limit", elapsed: time.Hour, wantAllowed: true, wantRemaining: 2},
+	}
+
+	s := NewMemoryStore()
+	for _, tt := range tests {
+		if b, ok := s.buckets["test:key"]; ok {
+			b.last = b.last.Add(-tt.elapsed)
+		}
+		r, err := s.Take(context.Background(), p, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Allowed != tt.wantAllowed || r.Remaining != tt.wantRemaining {
+			t.Errorf("%s: got allowed %v with %d remaining, want %v with %d", tt.name, r.Allowed, r.Remaining, tt.wantAllowed, tt.wantRemaining)
+		}
+		if !r.Allowed && (r.RetryAfter <= 0 || r.RetryAfter > time.Second) {
+			t.Errorf("%s: got retry after %v, want at most a token's time", tt.name, r.RetryAfter)
+		}
+	}
+
+	// buckets are per key
+	if r, _ := s.Take(context.Background(), p, "other"); !r.Allowed || r.Remaining != 2 {
+		t.Errorf("another key got allowed %v with %d remaining, want a full bucket", r.Allowed, r.Remaining)
+	}
+}
+
+func TestResult(t *testing.T) {
+	p := Policy{Name: "test", Limit: 10, Window: 10 * time.Second}
+
+	r := result(p, false, 0.25)
+	if r.Remaining != 0 || r.RetryAfter != 750*time.Millisecond || r.Reset != 9750*time.Millisecond {
+		t.Errorf("got %+v", r)
+	}
+	r = result(p, true, 9)
+	if r.Remaining != 9 || r.RetryAfter != 0 || r.Reset != time.Second {
+		t.Errorf("got %+v", r)
+	}
+}
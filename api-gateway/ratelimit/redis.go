@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"api-gateway/redis"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// takeScript refills and takes a token from the bucket stored in the hash
+// KEYS[1] atomically, using the server clock so replicas agree on time.
+// ARGV[1] is the capacity and ARGV[2] the refill rate per second. It returns
+// whether the token was taken and the tokens left, as a string since Lua
+// numbers are truncated to integers in replies.
+const takeScript = `
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+var takeScriptSHA = func() string {
+	sum := sha1.Sum([]byte(takeScript))
+	return hex.EncodeToString(sum[:])
+}()
+
+// RedisStore keeps buckets in Redis, or any server speaking its protocol
+// and running Lua scripts, so every gateway replica shares them.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+func (s *RedisStore) Take(ctx context.Context, p Policy, key string) (Result, error) {
+	key = "ratelimit:" + p.Name + ":" + key
+	args := []string{"1", key, strconv.Itoa(p.Limit), strconv.FormatFloat(p.rate(), 'g', -1, 64)}
+
+	reply, err := s.client.Do(ctx, append([]string{"EVALSHA", takeScriptSHA}, args...)...)
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "NOSCRIPT") {
+		// EVAL caches the script for the next EVALSHA
+		reply, err = s.client.Do(ctx, append([]string{"EVAL", takeScript}, args...)...)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := reply.([]any)
+	if !ok || len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected redis reply %v", reply)
+	}
+	allowed, _ := values[0].(int64)
+	tokensStr, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected redis reply %v", reply)
+	}
+	return result(p, allowed == 1, tokens), nil
+}
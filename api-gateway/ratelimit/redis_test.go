@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"api-gateway/redis"
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis answers PING, and EVAL and EVALSHA of the take script with the
+// given reply. EVALSHA fails with NOSCRIPT until the script was loaded.
+type fakeRedis struct {
+	reply string
+
+	mu       sync.Mutex
+	loaded   bool
+	commands []string
+	keys     []string
+}
+
+func (f *fakeRedis) serve(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn)
+		}
+	}()
+	return "redis://" + l.Addr().String()
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, args[0])
+		if len(args) > 3 {
+			f.keys = append(f.keys, args[3])
+		}
+		var reply string
+		switch {
+		case args[0] == "PING":
+			reply = "+PONG\r\n"
+		case args[0] == "EVALSHA" && !f.loaded:
+			reply = "-NOSCRIPT No matching script\r\n"
+		case args[0] == "EVAL" && args[1] == takeScript:
+			f.loaded = true
+			reply = f.reply
+		case args[0] == "EVALSHA" && args[1] == takeScriptSHA:
+			reply = f.reply
+		default:
+			reply = "-ERR unexpected command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisStoreTake(t *testing.T) {
+	p := Policy{Name: "test", Limit: 10, Window: 10 * time.Second}
+
+	tests := []struct {
+		name    string
+		reply   string
+		want    Result
+		wantErr bool
+	}{
+		{name: "allowed", reply: "*2\r\n:1\r\n$3\r\n4.5\r\n", want: Result{Allowed: true, Remaining: 4, Reset: 5500 * time.Millisecond}},
+		{name: "rejected", reply: "*2\r\n:0\r\n$4\r\n0.25\r\n", want: Result{Remaining: 0, RetryAfter: 750 * time.Millisecond, Reset: 9750 * time.Millisecond}},
+		{name: "unexpected reply", reply: ":1\r\n", wantErr: true},
+		{name: "error", reply: "-ERR script failed\r\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeRedis{reply: tt.reply}
+			client, err := redis.Open(server.serve(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := NewRedisStore(client)
+
+			// the first call loads the script, the second one uses its sha
+			for i := 0; i < 2; i++ {
+				got, err := store.Take(context.Background(), p, "key")
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("call %d: got error %v, want error %v", i, err, tt.wantErr)
+				}
+				if got != tt.want {
+					t.Errorf("call %d: got %+v, want %+v", i, got, tt.want)
+				}
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if got := strings.Join(server.commands, " "); got != "PING EVALSHA EVAL EVALSHA" {
+				t.Errorf("got commands %s, want the script loaded once", got)
+			}
+			for _, key := range server.keys {
+				if key != "ratelimit:test:key" {
+					t.Errorf("got key %s, want it prefixed by the policy", key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConnectionRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		want    any
+		wantErr string
+	}{
+		{name: "simple string", reply: "+OK\r\n", want: "OK"},
+		{name: "error", reply: "-ERR unknown command\r\n", wantErr: "redis: ERR unknown command"},
+		{name: "integer", reply: ":42\r\n", want: int64(42)},
+		{name: "bulk string", reply: "$5\r\nhello\r\n", want: "hello"},
+		{name: "bulk string with CRLF", reply: "$7\r\nhel\r\nlo\r\n", want: "hel\r\nlo"},
+		{name: "null bulk string", reply: "$-1\r\n", want: nil},
+		{name: "array", reply: "*3\r\n$3\r\nfoo\r\n:1\r\n$-1\r\n", want: []any{"foo", int64(1), nil}},
+		{name: "nested array", reply: "*2\r\n*1\r\n+a\r\n:2\r\n", want: []any{[]any{"a"}, int64(2)}},
+		{name: "array with an error", reply: "*3\r\n+OK\r\n-WRONGTYPE bad value\r\n:3\r\n", want: []any{"OK", Error("WRONGTYPE bad value"), int64(3)}},
+		{name: "empty array", reply: "*0\r\n", want: []any{}},
+		{name: "unknown type", reply: "!oops\r\n", wantErr: `redis: unexpected reply "!oops"`},
+		{name: "empty line", reply: "\r\n", wantErr: "redis: empty reply"},
+		{name: "truncated bulk string", reply: "$5\r\nhel", wantErr: "unexpected EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &connection{r: bufio.NewReader(strings.NewReader(tt.reply))}
+			got, err := conn.read()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConnectionReadStaysInSync checks an error inside an array doesn't
+// leave the rest of it to be read as the reply of the next command.
+func TestConnectionReadStaysInSync(t *testing.T) {
+	conn := &connection{r: bufio.NewReader(strings.NewReader("*2\r\n-ERR first\r\n+second\r\n+next\r\n"))}
+	if _, err := conn.read(); err != nil {
+		t.Fatal(err)
+	}
+	next, err := conn.read()
+	if err != nil || next != "next" {
+		t.Errorf("got %v, %v for the next reply, want next", next, err)
+	}
+}
@@ -0,0 +1,92 @@
+package transcode
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Route is an HTTP binding of an RPC.
+type Route struct {
+	Method string
+	// Path is the echo path, e.g. "/profiles/:id"
+	Path string
+	// FullMethod is the gRPC method, e.g. "/profile.ProfileService/GetProfile"
+	FullMethod string
+	// Body is the field of the input bound to the request body, "*" for every
+	// field left out of the path, "" for none
+	Body string
+	// PathParams are the fields of the input bound to the path
+	PathParams []string
+	RPC        protoreflect.MethodDescriptor
+}
+
+// pathVariable matches the variables of a path template, "{id}" or "{id=*}"
+var pathVariable = regexp.MustCompile(`\{([a-z0-9_.]+)(=\*)?\}`)
+
+// Routes returns the HTTP bindings of the methods of services, in the order
+// they are declared.
+func Routes(services ...protoreflect.ServiceDescriptor) ([]Route, error) {
+	routes := []Route{}
+	for _, sd := range services {
+		methods := sd.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			md := methods.Get(i)
+			opts := md.Options()
+			if opts == nil || !proto.HasExtension(opts, annotations.E_Http) {
+				continue
+			}
+
+			rule := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+			for _, rule := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+				route, err := newRoute(md, rule)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", md.FullName(), err)
+				}
+				routes = append(routes, route)
+			}
+		}
+	}
+	return routes, nil
+}
+
+func newRoute(md protoreflect.MethodDescriptor, rule *annotations.HttpRule) (Route, error) {
+	route := Route{
+		FullMethod: fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name()),
+		Body:       rule.GetBody(),
+		RPC:        md,
+	}
+
+	var template string
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		route.Method, template = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		route.Method, template = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		route.Method, template = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		route.Method, template = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		route.Method, template = http.MethodDelete, pattern.Delete
+	default:
+		return Route{}, fmt.Errorf("unsupported http rule %v", rule)
+	}
+
+	// echo routes only support single segment variables, deeper templates
+	// would be served by the mux but not reached through echo
+	route.Path = pathVariable.ReplaceAllStringFunc(template, func(variable string) string {
+		name := pathVariable.FindStringSubmatch(variable)[1]
+		route.PathParams = append(route.PathParams, name)
+		return ":" + name
+	})
+	if strings.ContainsAny(route.Path, "{}") {
+		return Route{}, fmt.Errorf("unsupported path template %s", template)
+	}
+	return route, nil
+}
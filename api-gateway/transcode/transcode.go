@@ -0,0 +1,191 @@
+// Package transcode serves the RPCs annotated with google.api.http options
+// in contracts/proto through the reverse proxies generated by grpc-gateway,
+// so new RPCs become REST endpoints without a hand-written handler.
+package transcode
+
+import (
+	"api-gateway/caching"
+	"api-gateway/utils"
+	"context"
+	"contracts/pb"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Policy is how the gateway exposes a transcoded RPC.
+type Policy struct {
+	// Public RPCs can be called without a bearer token
+	Public bool
+	// Limit names the rate limit policy of the RPC, applied on top of the
+	// default one
+	Limit string
+	// Status of successful responses, 200 by default
+	Status int
+	// Caller names the fields of the request set to the id of the
+	// authenticated user, clients can't act on behalf of someone else
+	Caller []string
+	// Validators returns the validators of a successful response, set as
+	// its ETag and Last-Modified headers, nil when the RPC has none
+	Validators func(resp proto.Message) (caching.Validators, bool)
+}
+
+// Clients are the services whose annotated RPCs are transcoded.
+type Clients struct {
+	User    pb.UserServiceClient
+	Profile pb.ProfileServiceClient
+	Swipe   pb.SwipeServiceClient
+	Payment pb.SubPaymentClient
+}
+
+// Gateway transcodes REST requests to the RPCs of Clients. Routes are
+// registered on Echo with Handler, so they run the same middlewares as
+// hand-written ones.
+type Gateway struct {
+	mux      *runtime.ServeMux
+	routes   []Route
+	policies map[string]Policy
+}
+
+// New builds the gateway of the annotated RPCs of clients, policies are
+// keyed by full method name.
+func New(clients Clients, policies map[string]Policy) (*Gateway, error) {
+	g := &Gateway{
+		mux: runtime.NewServeMux(
+			// bodies are encoded like the hand-written handlers do, with
+			// the snake_case json tags of the generated structs
+			runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONBuiltin{}),
+			runtime.WithIncomingHeaderMatcher(incomingHeader),
+			runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+				return utils.OutgoingMetadata(callFrom(r.Context()).c)
+			}),
+			runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+				if call := callFrom(ctx); call != nil && call.policy.Validators != nil {
+					if v, ok := call.policy.Validators(resp); ok {
+						v.Set(w.Header())
+					}
+				}
+				return nil
+			}),
+			runtime.WithErrorHandler(func(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, _ http.ResponseWriter, r *http.Request, err error) {
+				callFrom(r.Context()).err = utils.NewServiceError(err)
+			}),
+		),
+		policies: policies,
+	}
+
+	ctx := context.Background()
+	for _, register := range []func() error{
+		func() error { return pb.RegisterUserServiceHandlerClient(ctx, g.mux, clients.User) },
+		func() error { return pb.RegisterProfileServiceHandlerClient(ctx, g.mux, clients.Profile) },
+		func() error { return pb.RegisterSwipeServiceHandlerClient(ctx, g.mux, clients.Swipe) },
+		func() error { return pb.RegisterSubPaymentHandlerClient(ctx, g.mux, clients.Payment) },
+	} {
+		if err := register(); err != nil {
+			return nil, err
+		}
+	}
+
+	routes, err := Routes(
+		pb.File_user_proto.Services().ByName("UserService"),
+		pb.File_profile_proto.Services().ByName("ProfileService"),
+		pb.File_swipe_proto.Services().ByName("SwipeService"),
+		pb.File_subs_payment_proto.Services().ByName("SubPayment"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range routes {
+		if err := checkCaller(route.RPC.Input(), policies[route.FullMethod].Caller); err != nil {
+			return nil, fmt.Errorf("%s: %w", route.FullMethod, err)
+		}
+	}
+	g.routes = routes
+
+	return g, nil
+}
+
+// incomingHeader forwards no header as metadata: only the metadata built by
+// the gateway reaches the services, clients can't forge it with
+// Grpc-Metadata-* headers.
+func incomingHeader(string) (string, bool) {
+	return "", false
+}
+
+// Routes returns the routes of the transcoded RPCs.
+func (g *Gateway) Routes() []Route {
+	return g.routes
+}
+
+// Policy returns the policy of the RPC of route.
+func (g *Gateway) Policy(route Route) Policy {
+	return g.policies[route.FullMethod]
+}
+
+// call is the state of a transcoded request shared with the mux options
+// and UnaryClientInterceptor through the request context.
+type call struct {
+	c      echo.Context
+	policy Policy
+	err    error
+}
+
+type callKey struct{}
+
+func callFrom(ctx context.Context) *call {
+	c, _ := ctx.Value(callKey{}).(*call)
+	return c
+}
+
+// Handler serves route through the mux. Errors are returned to Echo, which
+// answers them like those of hand-written handlers.
+func (g *Gateway) Handler(route Route) echo.HandlerFunc {
+	policy := g.policies[route.FullMethod]
+	return func(c echo.Context) error {
+		if policy.Status != 0 {
+			res := c.Response()
+			res.Before(func() {
+				if res.Status == http.StatusOK {
+					res.Status = policy.Status
+				}
+			})
+		}
+
+		call := &call{c: c, policy: policy}
+		r := c.Request()
+		r = r.WithContext(context.WithValue(r.Context(), callKey{}, call))
+		// routes may be mounted under a prefix, such as an API version,
+		// the mux only knows the paths of the HTTP bindings
+		if prefix := strings.TrimSuffix(c.Path(), route.Path); prefix != "" {
+			u := *r.URL
+			u.Path = strings.TrimPrefix(u.Path, prefix)
+			u.RawPath = strings.TrimPrefix(u.RawPath, prefix)
+			r.URL = &u
+		}
+		g.mux.ServeHTTP(c.Response(), r)
+		return call.err
+	}
+}
+
+// checkCaller makes sure the caller fields of a policy exist and can hold
+// a user id.
+func checkCaller(md protoreflect.MessageDescriptor, fields []string) error {
+	for _, name := range fields {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("%s has no field %s", md.FullName(), name)
+		}
+		switch fd.Kind() {
+		case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Int64Kind:
+		default:
+			return fmt.Errorf("field %s of %s can't hold a user id", name, md.FullName())
+		}
+	}
+	return nil
+}
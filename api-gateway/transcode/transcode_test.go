@@ -0,0 +1,84 @@
+package transcode
+
+import (
+	"context"
+	"contracts/pb"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIncomingHeader(t *testing.T) {
+	for _, header := range []string{
+		"Grpc-Metadata-Auth_token",
+		"Grpc-Metadata-User_id",
+		"Grpc-Metadata-User_role",
+		"Grpc-Metadata-Client_ip",
+		"Grpc-Metadata-Service_token",
+		"Authorization",
+		"User-Agent",
+		"X-Forwarded-For",
+		"Grpc-Timeout",
+	} {
+		if key, ok := incomingHeader(header); ok {
+			t.Errorf("%s is forwarded as %s", header, key)
+		}
+	}
+}
+
+// fakeProfileClient records the metadata GetProfile is called with.
+type fakeProfileClient struct {
+	pb.ProfileServiceClient
+	md metadata.MD
+}
+
+func (f *fakeProfileClient) GetProfile(ctx context.Context, req *pb.GetProfileRequest, opts ...grpc.CallOption) (*pb.GetProfileResponse, error) {
+	f.md, _ = metadata.FromOutgoingContext(ctx)
+	return &pb.GetProfileResponse{Profile: &pb.Profile{Id: req.Id}}, nil
+}
+
+func TestGatewayDropsForgedMetadata(t *testing.T) {
+	profiles := &fakeProfileClient{}
+	g, err := New(Clients{Profile: profiles}, map[string]Policy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	for _, route := range g.Routes() {
+		if route.FullMethod == pb.ProfileService_GetProfile_FullMethodName {
+			e.Add(route.Method, route.Path, g.Handler(route))
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/profiles/7", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("User-Agent", "test")
+	req.Header.Set("Grpc-Metadata-Auth_token", "forged")
+	req.Header.Set("Grpc-Metadata-User_id", "1")
+	req.Header.Set("Grpc-Metadata-User_role", "admin")
+	req.Header.Set("Grpc-Metadata-Client_ip", "1.2.3.4")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", rec.Code, rec.Body.String())
+	}
+	for key, want := range map[string][]string{
+		"auth_token": {"token"},
+		"client_ip":  {"10.0.0.1"},
+		"user_agent": {"test"},
+		"user_id":    nil,
+		"user_role":  nil,
+	} {
+		if got := profiles.md.Get(key); !reflect.DeepEqual(got, want) && (len(got) != 0 || len(want) != 0) {
+			t.Errorf("got %s %v, want %v", key, got, want)
+		}
+	}
+}
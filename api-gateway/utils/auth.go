@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"contracts/pb"
+	"fmt"
 	"log"
 	"strings"
 
@@ -9,6 +11,9 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// echo.Context key of the user verified by the auth middleware
+const userContextKey = "user"
+
 func ExtractAuthToken(c echo.Context) string {
 	authHeader := c.Request().Header.Get("Authorization")
 
@@ -24,24 +29,49 @@ func ExtractAuthToken(c echo.Context) string {
 	return token
 }
 
+// SetUser stores the verified user in the echo.Context.
+func SetUser(c echo.Context, user *pb.User) {
+	c.Set(userContextKey, user)
+}
+
+// GetUser returns the user verified by the auth middleware, or nil on
+// public routes.
+func GetUser(c echo.Context) *pb.User {
+	user, _ := c.Get(userContextKey).(*pb.User)
+	return user
+}
+
+// CreateContext builds the outgoing gRPC context of a request, with the
+// metadata of OutgoingMetadata. Calls are canceled along the request, when
+// the client goes away.
 func CreateContext(c echo.Context) context.Context {
-	//get token from header
+	return metadata.NewOutgoingContext(c.Request().Context(), OutgoingMetadata(c))
+}
 
+// OutgoingMetadata is the metadata the gateway sends along a request. It
+// carries the address and user agent of the end user so backend services
+// can throttle per client and record sessions, plus the bearer token and
+// verified identity when there is one.
+func OutgoingMetadata(c echo.Context) metadata.MD {
+	md := metadata.Pairs(
+		"client_ip", c.RealIP(),
+		"user_agent", c.Request().UserAgent(),
+	)
+
+	//get token from header
 	authHeader := c.Request().Header.Get("Authorization")
 
-	// Check if the header is in the correct format
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		log.Print("No token found, returning empty context")
-		// if not return emtpty context
-		return context.TODO()
+	// attach token to context if the header is in the correct format
+	if strings.HasPrefix(authHeader, "Bearer ") {
+		// Extract the token part from the header (after "Bearer ")
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+		md.Append("auth_token", token)
 	}
 
-	// Extract the token part from the header (after "Bearer ")
-	token := strings.TrimPrefix(authHeader, "Bearer ")
-	log.Printf("Token found '%s', attaching to context", token)
-	// attach token to context
-	md := metadata.Pairs("auth_token", token)
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
-
-	return ctx
+	// forward the identity verified by the auth middleware
+	if user := GetUser(c); user != nil {
+		md.Append("user_id", fmt.Sprintf("%d", user.Id))
+		md.Append("user_role", user.Role)
+	}
+	return md
 }
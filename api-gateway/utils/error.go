@@ -1,16 +1,31 @@
 package utils
 
 import (
+	"contracts/rpcerr"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// AppError is the body of every error response:
+//
+//	{"code": "INVALID_ARGUMENT", "reason": "", "message": "...", "detail": "...", "fields": [...]}
+//
+// Code is the gRPC code name matching the HTTP status, Reason tells apart
+// errors sharing a code (e.g. "ALREADY_SWIPED") and Fields lists the invalid
+// fields of the request.
 type AppError struct {
-	StatusCode int    `json:"-"`
-	Message    string `json:"message"`
-	Detail     string `json:"detail,omitempty"`
+	StatusCode int                     `json:"-"`
+	Code       string                  `json:"code"`
+	Reason     string                  `json:"reason,omitempty"`
+	Message    string                  `json:"message"`
+	Detail     string                  `json:"detail,omitempty"`
+	Fields     []rpcerr.FieldViolation `json:"fields,omitempty"`
 }
 
 func (ae *AppError) Error() string {
@@ -20,18 +35,145 @@ func (ae *AppError) Error() string {
 func NewAppError(status int, message string, detail string) *AppError {
 	return &AppError{
 		StatusCode: status,
+		Code:       codeName(CodeFromHTTPStatus(status)),
 		Message:    message,
 		Detail:     detail,
 	}
 }
+
+// NewServiceError converts an error returned by a backend service, keeping
+// its message, reason and field violations. The message of internal errors
+// is logged rather than returned.
+func NewServiceError(err error) *AppError {
+	st := status.Convert(err)
+	appErr := &AppError{
+		StatusCode: HTTPStatusFromCode(st.Code()),
+		Code:       codeName(st.Code()),
+		Reason:     rpcerr.Reason(err),
+		Message:    st.Message(),
+		Fields:     rpcerr.FieldViolations(err),
+	}
+
+	switch st.Code() {
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		log.Printf("service error: %s", st.Message())
+		appErr.Message = "internal error"
+	}
+	return appErr
+}
+
+// HTTPStatusFromCode returns the HTTP status of a gRPC code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CodeFromHTTPStatus returns the gRPC code of an HTTP status, for errors
+// raised by the gateway itself.
+func CodeFromHTTPStatus(status int) codes.Code {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if status < http.StatusBadRequest {
+		return codes.OK
+	}
+	if status < http.StatusInternalServerError {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// codeNames spell the gRPC codes in UPPER_SNAKE_CASE
+var codeNames = map[codes.Code]string{
+	codes.OK:                 "OK",
+	codes.Canceled:           "CANCELED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// codeName spells code in UPPER_SNAKE_CASE, e.g. "INVALID_ARGUMENT".
+func codeName(code codes.Code) string {
+	if name, ok := codeNames[code]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
 func ErrorHandler(e error, c echo.Context) {
-	if httperror, ok := e.(*echo.HTTPError); ok {
-		c.JSON(httperror.Code, map[string]any{
-			"message": httperror.Message,
-		})
-	} else if apperror, ok := e.(*AppError); ok {
-		c.JSON(apperror.StatusCode, apperror)
-	} else {
-		c.JSON(http.StatusInternalServerError, e.Error())
+	if c.Response().Committed {
+		return
 	}
+
+	var appErr *AppError
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(e, &appErr):
+	case errors.As(e, &httpErr):
+		appErr = NewAppError(httpErr.Code, fmt.Sprint(httpErr.Message), "")
+	default:
+		if _, ok := status.FromError(e); ok {
+			appErr = NewServiceError(e)
+		} else {
+			log.Printf("unhandled error: %s", e.Error())
+			appErr = NewAppError(http.StatusInternalServerError, "internal error", "")
+		}
+	}
+
+	c.JSON(appErr.StatusCode, appErr)
 }
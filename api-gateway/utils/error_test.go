@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewAppErrorCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{status: http.StatusOK, want: "OK"},
+		{status: http.StatusBadRequest, want: "INVALID_ARGUMENT"},
+		{status: http.StatusUnauthorized, want: "UNAUTHENTICATED"},
+		{status: http.StatusForbidden, want: "PERMISSION_DENIED"},
+		{status: http.StatusTooManyRequests, want: "RESOURCE_EXHAUSTED"},
+		{status: http.StatusGatewayTimeout, want: "DEADLINE_EXCEEDED"},
+		{status: http.StatusInternalServerError, want: "INTERNAL"},
+	}
+
+	for _, tt := range tests {
+		if got := NewAppError(tt.status, "message", "").Code; got != tt.want {
+			t.Errorf("status %d: got code %s, want %s", tt.status, got, tt.want)
+		}
+	}
+
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if _, ok := codeNames[code]; !ok {
+			t.Errorf("no name for code %v", code)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"contracts/validate"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Validator is the echo.Validator of the gateway. It checks protobuf
+// requests against the validate.rules of their contract, the same rules
+// the services enforce, so invalid requests are rejected with every field
+// violation before reaching a backend.
+type Validator struct{}
+
+func (Validator) Validate(i any) error {
+	m, ok := i.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	if err := validate.Message(m); err != nil {
+		return NewServiceError(err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Envelope is the body of every JSON response of enveloped versions. Data
+// is the body of successful responses and null otherwise, Errors lists the
+// errors of failed ones and is empty otherwise.
+type Envelope struct {
+	Data   json.RawMessage   `json:"data"`
+	Meta   Meta              `json:"meta"`
+	Errors []json.RawMessage `json:"errors"`
+}
+
+// Meta describes the response.
+type Meta struct {
+	Version string `json:"version"`
+	Status  int    `json:"status"`
+}
+
+// recorder holds back the response of a handler until it is wrapped.
+// Headers go straight to the underlying writer.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// envelope runs next, answering its errors through the error handler of
+// echo, and wraps the JSON body written by either in an Envelope. Other
+// bodies, such as archives or pages, are written as they are.
+func envelope(c echo.Context, v Version, next echo.HandlerFunc) error {
+	res := c.Response()
+	w := res.Writer
+	rec := &recorder{ResponseWriter: w}
+	res.Writer = rec
+	defer func() { res.Writer = w }()
+
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+	res.Writer = w
+
+	if rec.status == 0 {
+		// nothing was written
+		return nil
+	}
+	body := rec.body.Bytes()
+	if len(body) > 0 && strings.HasPrefix(w.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON) && json.Valid(body) {
+		env := Envelope{
+			Meta:   Meta{Version: v.Name, Status: rec.status},
+			Errors: []json.RawMessage{},
+		}
+		if rec.status >= http.StatusBadRequest {
+			env.Data = json.RawMessage("null")
+			env.Errors = append(env.Errors, body)
+		} else {
+			env.Data = body
+		}
+
+		wrapped, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+		body = wrapped
+		w.Header().Del(echo.HeaderContentLength)
+	}
+
+	w.WriteHeader(rec.status)
+	_, err := w.Write(body)
+	return err
+}
@@ -0,0 +1,89 @@
+// Package versioning serves the generations of the gateway API side by side,
+// each under its own path prefix, so mobile apps already installed keep the
+// response shapes they were built against.
+package versioning
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Version is a generation of the API.
+type Version struct {
+	// Name identifies the version in metrics and in the meta of envelopes
+	Name string
+	// Prefix of its routes, e.g. "/v2", "" for the unversioned routes
+	Prefix string
+	// Envelope wraps JSON bodies in an Envelope
+	Envelope bool
+	// Deprecated versions answer with a Deprecation header, and a Link
+	// header to the same route under Successor when set
+	Deprecated bool
+	Successor  string
+	// Sunset is the date the version will be removed, zero when none is
+	// planned yet. Versions with a sunset are deprecated.
+	Sunset time.Time
+}
+
+// FromEnv returns def with the sunset date set in API_SUNSET_<NAME>, e.g.
+// API_SUNSET_V1=2027-06-30.
+func FromEnv(def Version) (Version, error) {
+	env := "API_SUNSET_" + strings.ToUpper(def.Name)
+	s := os.Getenv(env)
+	if s == "" {
+		return def, nil
+	}
+
+	sunset, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Version{}, fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", env, s)
+	}
+	def.Sunset = sunset
+	def.Deprecated = true
+	return def, nil
+}
+
+var (
+	// requests counts the requests served by each version
+	requests = expvar.NewMap("api_requests_by_version")
+	// routes counts them by version and route, e.g. "v1 GET /profiles/:id",
+	// to tell which routes are still used before a sunset
+	routes = expvar.NewMap("api_requests_by_route")
+)
+
+// Middleware counts the requests of v, sets its deprecation headers and
+// wraps the responses of enveloped versions. It must be the first middleware
+// of the routes of v, so their errors are wrapped too.
+func (v Version) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			defer func() {
+				requests.Add(v.Name, 1)
+				routes.Add(fmt.Sprintf("%s %s %s", v.Name, c.Request().Method, strings.TrimPrefix(c.Path(), v.Prefix)), 1)
+			}()
+
+			if v.Deprecated {
+				header := c.Response().Header()
+				header.Set("Deprecation", "true")
+				if !v.Sunset.IsZero() {
+					header.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+				}
+				if v.Successor != "" {
+					path := v.Successor + strings.TrimPrefix(c.Request().URL.Path, v.Prefix)
+					header.Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", path))
+				}
+			}
+
+			if !v.Envelope {
+				return next(c)
+			}
+			return envelope(c, v, next)
+		}
+	}
+}
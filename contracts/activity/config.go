@@ -0,0 +1,69 @@
+package activity
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config tunes a Logger.
+type Config struct {
+	// QueueSize is the number of logs waiting for delivery, logs added to
+	// a full queue are spilled or dropped
+	QueueSize int
+	// BatchSize is the number of logs sent by an AddLogs call at most
+	BatchSize int
+	// FlushInterval is how long a log waits for its batch to fill up
+	FlushInterval time.Duration
+	// SpillDir is where logs that can't be delivered are kept until
+	// logs-service is back, they are dropped when empty
+	SpillDir string
+	// ServiceToken authenticates the service to logs-service, which only
+	// accepts logs from the services
+	ServiceToken string
+}
+
+// DefaultConfig queues 1000 logs and sends them every second, by batches
+// of 100 at most. Logs that can't be delivered are dropped.
+var DefaultConfig = Config{
+	QueueSize:     1000,
+	BatchSize:     100,
+	FlushInterval: time.Second,
+}
+
+// ConfigFromEnv overrides the DefaultConfig with LOG_QUEUE_SIZE,
+// LOG_BATCH_SIZE, LOG_FLUSH_INTERVAL and LOG_SPILL_DIR, and reads the
+// SERVICE_TOKEN.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig
+	config.SpillDir = os.Getenv("LOG_SPILL_DIR")
+	config.ServiceToken = os.Getenv("SERVICE_TOKEN")
+
+	for name, size := range map[string]*int{
+		"LOG_QUEUE_SIZE": &config.QueueSize,
+		"LOG_BATCH_SIZE": &config.BatchSize,
+	} {
+		s := os.Getenv(name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			return Config{}, fmt.Errorf("invalid %s %q, want a positive number", name, s)
+		}
+		*size = n
+	}
+	if config.BatchSize > maxBatchSize {
+		return Config{}, fmt.Errorf("invalid LOG_BATCH_SIZE %d, want %d at most", config.BatchSize, maxBatchSize)
+	}
+
+	if s := os.Getenv("LOG_FLUSH_INTERVAL"); s != "" {
+		interval, err := time.ParseDuration(s)
+		if err != nil || interval <= 0 {
+			return Config{}, fmt.Errorf("invalid LOG_FLUSH_INTERVAL %q, want a positive duration", s)
+		}
+		config.FlushInterval = interval
+	}
+	return config, nil
+}
@@ -0,0 +1,257 @@
+// Package activity delivers the activity logs of the services to
+// logs-service in the background, so that audit logging never blocks or
+// fails the operations it records.
+package activity
+
+import (
+	"context"
+	"contracts/clients"
+	"contracts/pb"
+	"contracts/validate"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxBatchSize is the max_items of AddLogsRequest.logs
+	maxBatchSize = 500
+	// deliveries of a batch are attempted maxAttempts times while
+	// logs-service is unavailable, waiting twice as long each time
+	maxAttempts    = 3
+	initialBackoff = 200 * time.Millisecond
+	// spilled logs are sent again after a delivery succeeded, or every
+	// replayInterval to probe logs-service
+	replayInterval = 30 * time.Second
+	// logs-service rejects the logs that occurred more than maxLogAge ago,
+	// spilled logs older than that are dropped
+	maxLogAge = 7 * 24 * time.Hour
+)
+
+// Logger queues activity logs and sends them to logs-service by batches,
+// from a single goroutine. Logs are delivered at least once: a batch
+// timing out may have been added before it is retried.
+type Logger struct {
+	client pb.LogServiceClient
+	config Config
+	spill  *spill
+
+	// mu guards closed, so that Log doesn't send to the closed queue
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *pb.AddLogRequest
+
+	// ctx cancels the delivery in progress when Close gives up
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewLogger starts delivering the logs added to the returned Logger to
+// client, Close it to deliver the queued logs on shutdown.
+func NewLogger(client pb.LogServiceClient, config Config) *Logger {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Logger{
+		client: client,
+		config: config,
+		queue:  make(chan *pb.AddLogRequest, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	if config.SpillDir != "" {
+		l.spill = newSpill(config.SpillDir)
+	}
+
+	go l.run()
+	return l
+}
+
+// Log queues entry for delivery and returns at once. Entries added to a
+// full queue, or after Close, are spilled to disk or dropped. Invalid
+// entries are dropped at once, logs-service would reject their whole batch.
+func (l *Logger) Log(entry *pb.AddLogRequest) {
+	if violations := validate.Violations(entry); len(violations) > 0 {
+		dropped := l.dropped.Add(1)
+		log.Printf("activity: dropped invalid log, %s (%d in total)", violations[0].Description, dropped)
+		return
+	}
+	if entry.OccurredAt == nil {
+		entry.OccurredAt = timestamppb.Now()
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if !l.closed {
+		select {
+		case l.queue <- entry:
+			return
+		default:
+		}
+	}
+	l.discard([]*pb.AddLogRequest{entry}, "queue full")
+}
+
+// Dropped returns the number of logs dropped so far.
+func (l *Logger) Dropped() uint64 {
+	return l.dropped.Load()
+}
+
+// Close stops accepting logs and delivers the queued ones. Logs still
+// queued when ctx is done are spilled or dropped.
+func (l *Logger) Close(ctx context.Context) {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.queue)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-l.done:
+	case <-ctx.Done():
+		l.cancel()
+		<-l.done
+	}
+	l.cancel()
+}
+
+func (l *Logger) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.config.FlushInterval)
+	defer ticker.Stop()
+
+	var lastReplay time.Time
+	batch := make([]*pb.AddLogRequest, 0, l.config.BatchSize)
+	for {
+		select {
+		case entry, ok := <-l.queue:
+			if !ok {
+				l.flush(batch)
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) < l.config.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				if l.spill != nil && time.Since(lastReplay) >= replayInterval {
+					lastReplay = time.Now()
+					if l.replay() {
+						lastReplay = time.Time{}
+					}
+				}
+				continue
+			}
+		}
+
+		if l.flush(batch) {
+			// logs-service is back, send the spilled logs on the next tick
+			lastReplay = time.Time{}
+		}
+		batch = batch[:0]
+	}
+}
+
+// flush delivers batch, or spills it while logs-service is unavailable.
+// It tells whether logs-service answered.
+func (l *Logger) flush(batch []*pb.AddLogRequest) bool {
+	if len(batch) == 0 {
+		return true
+	}
+	err := l.deliver(batch)
+	if err == nil {
+		return true
+	}
+
+	log.Printf("activity: failed to deliver %d logs: %v", len(batch), err)
+	if !clients.Unavailable(err) && l.ctx.Err() == nil {
+		// logs-service rejected the batch, sending it again wouldn't help
+		dropped := l.dropped.Add(uint64(len(batch)))
+		log.Printf("activity: dropped %d rejected logs (%d in total)", len(batch), dropped)
+		return true
+	}
+	l.discard(batch, "delivery failed")
+	return false
+}
+
+// replay sends the spilled logs again, stopping at the first batch that
+// can't be delivered. It tells whether logs-service answered them all.
+func (l *Logger) replay() bool {
+	entries, err := l.spill.take()
+	if err != nil {
+		log.Printf("activity: failed to read spilled logs: %v", err)
+		return false
+	}
+	entries = l.dropStale(entries)
+
+	for len(entries) > 0 {
+		n := min(len(entries), l.config.BatchSize)
+		if !l.flush(entries[:n]) {
+			if len(entries) > n {
+				l.discard(entries[n:], "delivery failed")
+			}
+			return false
+		}
+		entries = entries[n:]
+	}
+	return true
+}
+
+// dropStale drops the entries logs-service would reject as too old, they
+// would get their whole batch rejected.
+func (l *Logger) dropStale(entries []*pb.AddLogRequest) []*pb.AddLogRequest {
+	oldest := time.Now().Add(-maxLogAge)
+	fresh := entries[:0]
+	for _, entry := range entries {
+		if entry.OccurredAt.AsTime().After(oldest) {
+			fresh = append(fresh, entry)
+		}
+	}
+
+	if stale := len(entries) - len(fresh); stale > 0 {
+		dropped := l.dropped.Add(uint64(stale))
+		log.Printf("activity: dropped %d logs older than 7 days (%d in total)", stale, dropped)
+	}
+	return fresh
+}
+
+func (l *Logger) deliver(batch []*pb.AddLogRequest) error {
+	ctx := metadata.AppendToOutgoingContext(l.ctx, "service_token", l.config.ServiceToken)
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		_, err := l.client.AddLogs(ctx, &pb.AddLogsRequest{Logs: batch})
+		if err == nil || !clients.Unavailable(err) || attempt == maxAttempts {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-l.ctx.Done():
+			return l.ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// discard spills entries, or drops them when there is no spill directory
+// or it is full.
+func (l *Logger) discard(entries []*pb.AddLogRequest, reason string) {
+	if l.spill != nil {
+		err := l.spill.add(entries)
+		if err == nil {
+			return
+		}
+		log.Printf("activity: failed to spill %d logs: %v", len(entries), err)
+	}
+
+	dropped := l.dropped.Add(uint64(len(entries)))
+	log.Printf("activity: %s, dropped %d logs (%d in total)", reason, len(entries), dropped)
+}
@@ -0,0 +1,157 @@
+package activity
+
+import (
+	"context"
+	"contracts/pb"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeLogClient struct {
+	pb.LogServiceClient
+
+	mu      sync.Mutex
+	err     error
+	batches [][]*pb.AddLogRequest
+	tokens  []string
+}
+
+func (f *fakeLogClient) AddLogs(ctx context.Context, req *pb.AddLogsRequest, opts ...grpc.CallOption) (*pb.AddLogsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.tokens = append(f.tokens, md.Get("service_token")...)
+	f.batches = append(f.batches, append([]*pb.AddLogRequest(nil), req.Logs...))
+	return &pb.AddLogsResponse{Count: uint32(len(req.Logs))}, nil
+}
+
+func (f *fakeLogClient) delivered() (batches, logs int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, batch := range f.batches {
+		logs += len(batch)
+	}
+	return len(f.batches), logs
+}
+
+func TestLoggerBatchesLogs(t *testing.T) {
+	client := &fakeLogClient{}
+	l := NewLogger(client, Config{QueueSize: 10, BatchSize: 2, FlushInterval: time.Hour, ServiceToken: "secret"})
+
+	for i := 0; i < 5; i++ {
+		l.Log(&pb.AddLogRequest{UserId: 1, ActionType: "Swipe", Details: "details"})
+	}
+	l.Close(context.Background())
+
+	batches, logs := client.delivered()
+	if batches != 3 || logs != 5 {
+		t.Errorf("got %d logs in %d batches, want 5 logs in 3 batches", logs, batches)
+	}
+	if client.batches[0][0].OccurredAt == nil {
+		t.Error("logs should be timestamped when added")
+	}
+	for _, token := range client.tokens {
+		if token != "secret" {
+			t.Errorf("got service token %q, want secret", token)
+		}
+	}
+	if len(client.tokens) != batches {
+		t.Errorf("got %d service tokens for %d batches", len(client.tokens), batches)
+	}
+}
+
+func TestLoggerSpillsUndeliveredLogs(t *testing.T) {
+	dir := t.TempDir()
+	config := Config{QueueSize: 1, BatchSize: 10, FlushInterval: time.Hour, SpillDir: dir}
+
+	down := &fakeLogClient{err: status.Error(codes.Unavailable, "connection refused")}
+	l := NewLogger(down, config)
+	for i := 0; i < 3; i++ {
+		l.Log(&pb.AddLogRequest{UserId: 1, ActionType: "Swipe", Details: "details"})
+	}
+	l.Close(context.Background())
+	if l.Dropped() != 0 {
+		t.Errorf("dropped %d logs, want them spilled", l.Dropped())
+	}
+
+	up := &fakeLogClient{}
+	l = NewLogger(up, config)
+	if !l.replay() {
+		t.Fatal("spilled logs weren't delivered")
+	}
+	l.Close(context.Background())
+
+	if _, logs := up.delivered(); logs != 3 {
+		t.Errorf("delivered %d spilled logs, want 3", logs)
+	}
+	if entries, _ := l.spill.take(); len(entries) != 0 {
+		t.Errorf("%d logs left in the spill file", len(entries))
+	}
+}
+
+func TestLoggerDropsWithoutSpillDir(t *testing.T) {
+	client := &fakeLogClient{err: status.Error(codes.InvalidArgument, "action_type is required")}
+	l := NewLogger(client, Config{QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour})
+
+	l.Log(&pb.AddLogRequest{UserId: 1, ActionType: "Swipe", Details: "details"})
+	l.Close(context.Background())
+	l.Log(&pb.AddLogRequest{UserId: 1, ActionType: "Swipe", Details: "details"})
+
+	if l.Dropped() != 2 {
+		t.Errorf("dropped %d logs, want 2", l.Dropped())
+	}
+}
+
+func TestLoggerDropsInvalidLogs(t *testing.T) {
+	client := &fakeLogClient{}
+	l := NewLogger(client, Config{QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour})
+
+	l.Log(&pb.AddLogRequest{UserId: 1, ActionType: "Swipe", Details: "details"})
+	l.Log(&pb.AddLogRequest{ActionType: "Swipe", Details: "details"})
+	l.Log(&pb.AddLogRequest{UserId: 1, ActionType: "Swipe", Details: "details"})
+	l.Close(context.Background())
+
+	if _, logs := client.delivered(); logs != 2 {
+		t.Errorf("delivered %d logs, want the 2 valid ones", logs)
+	}
+	if l.Dropped() != 1 {
+		t.Errorf("dropped %d logs, want 1", l.Dropped())
+	}
+}
+
+func TestLoggerDropsStaleSpilledLogs(t *testing.T) {
+	config := Config{QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour, SpillDir: t.TempDir()}
+	up := &fakeLogClient{}
+	l := NewLogger(up, config)
+	defer l.Close(context.Background())
+
+	err := l.spill.add([]*pb.AddLogRequest{
+		{UserId: 1, ActionType: "Swipe", Details: "details", OccurredAt: timestamppb.New(time.Now().Add(-maxLogAge - time.Hour))},
+		{UserId: 1, ActionType: "Swipe", Details: "details", OccurredAt: timestamppb.New(time.Now().Add(-time.Hour))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !l.replay() {
+		t.Fatal("spilled logs weren't delivered")
+	}
+	if _, logs := up.delivered(); logs != 1 {
+		t.Errorf("delivered %d spilled logs, want the recent one", logs)
+	}
+	if l.Dropped() != 1 {
+		t.Errorf("dropped %d logs, want the stale one", l.Dropped())
+	}
+}
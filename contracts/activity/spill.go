@@ -0,0 +1,101 @@
+package activity
+
+import (
+	"bytes"
+	"contracts/pb"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// spillFile holds the spilled logs in the spill directory, one JSON
+	// encoded AddLogRequest per line
+	spillFile = "activity-logs.jsonl"
+	// logs are dropped once the spill file reaches maxSpillSize
+	maxSpillSize = 64 << 20
+)
+
+var errSpillFull = errors.New("spill file is full")
+
+// spill keeps the logs that couldn't be delivered on disk, so they survive
+// an outage of logs-service and restarts.
+type spill struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newSpill(dir string) *spill {
+	return &spill{path: filepath.Join(dir, spillFile)}
+}
+
+// add appends entries to the spill file.
+func (s *spill) add(entries []*pb.AddLogRequest) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := protojson.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size()+int64(buf.Len()) > maxSpillSize {
+		return errSpillFull
+	}
+	_, err = f.Write(buf.Bytes())
+	return err
+}
+
+// take removes the spilled logs from the spill file and returns them.
+func (s *spill) take() ([]*pb.AddLogRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(s.path); err != nil {
+		return nil, err
+	}
+
+	var entries []*pb.AddLogRequest
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		entry := &pb.AddLogRequest{}
+		if err := protojson.Unmarshal(line, entry); err != nil {
+			// a line cut short by a crash, the others are still good
+			log.Printf("activity: skipping line %d of %s: %v", i+1, s.path, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,164 @@
+// Package auth authenticates and authorizes the gRPC calls of the services
+// against a policy table of rules keyed by full method name.
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RoleAdmin is the role of admins, as issued by users-service. Admins are
+// allowed every Premium and Admin RPC.
+const RoleAdmin = "admin"
+
+// Access is the level a caller needs before the Roles and Owner of a Rule
+// are looked at.
+type Access int
+
+const (
+	// Authenticated RPCs need a valid auth_token, it is the default for
+	// RPCs missing from the policy table
+	Authenticated Access = iota
+	// Public RPCs can be called without a token
+	Public
+	// Premium RPCs need a premium subscription, admins are always allowed
+	Premium
+	// Admin RPCs can only be called by admins
+	Admin
+)
+
+// Identity is what the interceptor needs to know about a caller.
+type Identity struct {
+	ID        uint
+	Role      string
+	IsPremium bool
+}
+
+// User is the user type of a service, as returned by its Users.
+type User interface {
+	Identity() Identity
+}
+
+// Users looks up the caller of an RPC from the auth_token of its metadata.
+type Users[U User] interface {
+	ValidateAndGetUser(ctx context.Context) (U, error)
+}
+
+// Rule describes who may call an RPC. The caller must have the rule's
+// Access, and when Roles or Owner are set, also have one of Roles or own
+// the requested resource according to Owner.
+type Rule[U User] struct {
+	Access Access
+	Roles  []string
+	Owner  func(ctx context.Context, req any, user U) (bool, error)
+}
+
+// Interceptor authenticates and authorizes every call against its rules,
+// and stores the caller in the context of the handlers (see
+// UserFromContext).
+type Interceptor[U User] struct {
+	users Users[U]
+	rules map[string]Rule[U]
+}
+
+func NewInterceptor[U User](users Users[U], rules map[string]Rule[U]) *Interceptor[U] {
+	return &Interceptor[U]{
+		users: users,
+		rules: rules,
+	}
+}
+
+// authorize checks the caller against the rule of method and returns the
+// context for the handler. req is nil for streaming RPCs, which can't have
+// an Owner check.
+func (a *Interceptor[U]) authorize(ctx context.Context, method string, req any) (context.Context, error) {
+	rule := a.rules[method]
+	if rule.Access == Public {
+		return ctx, nil
+	}
+
+	user, err := a.users.ValidateAndGetUser(ctx)
+	if err != nil {
+		// users-service failing or timing out isn't a rejected token, its
+		// error goes back to the caller unchanged
+		if st, ok := status.FromError(err); ok && st.Code() != codes.Unauthenticated {
+			return nil, err
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid auth token")
+	}
+	identity := user.Identity()
+
+	switch rule.Access {
+	case Premium:
+		if !identity.IsPremium && identity.Role != RoleAdmin {
+			return nil, status.Errorf(codes.PermissionDenied, "%s requires a premium subscription", method)
+		}
+	case Admin:
+		if identity.Role != RoleAdmin {
+			return nil, status.Errorf(codes.PermissionDenied, "role '%s' is not allowed to call %s", identity.Role, method)
+		}
+	}
+
+	if (len(rule.Roles) > 0 || rule.Owner != nil) && !slices.Contains(rule.Roles, identity.Role) {
+		if rule.Owner == nil || req == nil {
+			return nil, status.Errorf(codes.PermissionDenied, "role '%s' is not allowed to call %s", identity.Role, method)
+		}
+
+		owns, err := rule.Owner(ctx, req, user)
+		if err != nil {
+			return nil, err
+		}
+		if !owns {
+			return nil, status.Errorf(codes.PermissionDenied, "user %d does not own the requested resource", identity.ID)
+		}
+	}
+
+	return ContextWithUser(ctx, user), nil
+}
+
+func (a *Interceptor[U]) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *Interceptor[U]) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod, nil)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream hands the context carrying the caller to streaming
+// handlers.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+type userContextKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user.
+func ContextWithUser[U User](ctx context.Context, user U) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the user stored by the interceptor, the second
+// value is false on public RPCs.
+func UserFromContext[U User](ctx context.Context) (U, bool) {
+	user, ok := ctx.Value(userContextKey{}).(U)
+	return user, ok
+}
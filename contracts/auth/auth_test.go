@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testUser struct {
+	id        uint
+	role      string
+	isPremium bool
+}
+
+func (u *testUser) Identity() Identity {
+	return Identity{ID: u.id, Role: u.role, IsPremium: u.isPremium}
+}
+
+// testUsers authenticates every call as user, or fails with err, or when
+// user is nil.
+type testUsers struct {
+	user *testUser
+	err  error
+}
+
+func (t testUsers) ValidateAndGetUser(ctx context.Context) (*testUser, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.user == nil {
+		return nil, errors.New("auth_token not found in metadata")
+	}
+	return t.user, nil
+}
+
+type testRequest struct {
+	userID uint
+}
+
+func TestInterceptor(t *testing.T) {
+	rules := map[string]Rule[*testUser]{
+		"/Public":  {Access: Public},
+		"/Premium": {Access: Premium},
+		"/Admin":   {Access: Admin},
+		"/Owned": {
+			Roles: []string{"moderator"},
+			Owner: func(ctx context.Context, req any, user *testUser) (bool, error) {
+				return req.(*testRequest).userID == user.id, nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		user   *testUser
+		err    error
+		req    *testRequest
+		want   codes.Code
+	}{
+		{name: "public without token", method: "/Public", want: codes.OK},
+		{name: "missing rule without token", method: "/Other", want: codes.Unauthenticated},
+		{name: "token rejected by users-service", method: "/Other", err: status.Error(codes.Unauthenticated, "invalid token"), want: codes.Unauthenticated},
+		{name: "users-service unavailable", method: "/Other", err: status.Error(codes.Unavailable, "connection refused"), want: codes.Unavailable},
+		{name: "users-service timed out", method: "/Other", err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), want: codes.DeadlineExceeded},
+		{name: "missing rule", method: "/Other", user: &testUser{id: 1, role: "user"}, want: codes.OK},
+		{name: "premium", method: "/Premium", user: &testUser{id: 1, role: "user", isPremium: true}, want: codes.OK},
+		{name: "premium without subscription", method: "/Premium", user: &testUser{id: 1, role: "user"}, want: codes.PermissionDenied},
+		{name: "premium as admin", method: "/Premium", user: &testUser{id: 1, role: RoleAdmin}, want: codes.OK},
+		{name: "admin", method: "/Admin", user: &testUser{id: 1, role: RoleAdmin}, want: codes.OK},
+		{name: "admin as user", method: "/Admin", user: &testUser{id: 1, role: "user"}, want: codes.PermissionDenied},
+		{name: "owner", method: "/Owned", user: &testUser{id: 1, role: "user"}, req: &testRequest{userID: 1}, want: codes.OK},
+		{name: "not the owner", method: "/Owned", user: &testUser{id: 1, role: "user"}, req: &testRequest{userID: 2}, want: codes.PermissionDenied},
+		{name: "allowed role", method: "/Owned", user: &testUser{id: 1, role: "moderator"}, req: &testRequest{userID: 2}, want: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewInterceptor[*testUser](testUsers{user: tt.user, err: tt.err}, rules)
+
+			var handled *testUser
+			handler := func(ctx context.Context, req any) (any, error) {
+				handled, _ = UserFromContext[*testUser](ctx)
+				return nil, nil
+			}
+			_, err := interceptor.Unary(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %v, want %v", code, tt.want)
+			}
+			if err == nil && tt.user != nil && handled != tt.user {
+				t.Error("the handler context doesn't carry the caller")
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package clients
+
+import (
+	"context"
+	"contracts/rpcerr"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// a connection opens its circuit after failureThreshold calls in a row
+	// failed because the service couldn't answer
+	failureThreshold = 5
+	// an open circuit fails calls at once for openDuration, then lets a
+	// single call through to probe the service
+	openDuration = 10 * time.Second
+)
+
+// ReasonCircuitOpen is the reason of the errors of calls rejected by an
+// open circuit.
+const ReasonCircuitOpen = "CIRCUIT_OPEN"
+
+// Unavailable tells whether err means the service couldn't answer: it is
+// unreachable, too slow, or its circuit is open. Callers can fall back on
+// a degraded answer then.
+func Unavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// breaker is the circuit breaker of a connection, it spares a failing
+// service the calls it can't answer and its callers the wait.
+type breaker struct {
+	target string
+	// timeout of the calls per the service config
+	timeout time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newBreaker(target string, timeout time.Duration) *breaker {
+	return &breaker{target: target, timeout: timeout}
+}
+
+// allow returns the error of a call rejected by an open circuit.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < failureThreshold {
+		return nil
+	}
+	if time.Now().After(b.openUntil) && !b.probing {
+		b.probing = true
+		return nil
+	}
+	return rpcerr.WithReason(codes.Unavailable, ReasonCircuitOpen, "%s is unavailable, retry later", b.target)
+}
+
+// outcome is what a call tells about the health of the service.
+type outcome int
+
+const (
+	// the service answered, even with an error
+	callSucceeded outcome = iota
+	// the service couldn't answer
+	callFailed
+	// the caller gave up on the call before the service answered, which
+	// tells nothing about the service
+	callAbandoned
+)
+
+// outcome returns the outcome of a call started at start with ctx. The
+// caller canceling the call or setting a shorter deadline than the service
+// config isn't the service's fault, a client going away mustn't open the
+// circuit for everyone.
+func (b *breaker) outcome(ctx context.Context, start time.Time, err error) outcome {
+	switch status.Code(err) {
+	case codes.OK:
+		return callSucceeded
+	case codes.Canceled:
+		return callAbandoned
+	case codes.Unavailable:
+		return callFailed
+	case codes.DeadlineExceeded:
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(start.Add(b.timeout)) {
+			return callAbandoned
+		}
+		return callFailed
+	}
+	if ctx.Err() != nil {
+		return callAbandoned
+	}
+	return callSucceeded
+}
+
+// record counts the outcome of a call let through by allow. An abandoned
+// call leaves the circuit as it was, but frees the probe slot so that the
+// next call probes the service.
+func (b *breaker) record(o outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbing := b.probing
+	b.probing = false
+	switch o {
+	case callAbandoned:
+		return
+	case callSucceeded:
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= failureThreshold || wasProbing {
+		b.openUntil = time.Now().Add(openDuration)
+	}
+}
+
+func (b *breaker) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	b.record(b.outcome(ctx, start, err))
+	return err
+}
+
+// streamInterceptor only guards the opening of streams, their messages
+// flow as long as the service keeps them open.
+func (b *breaker) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	b.record(b.outcome(ctx, start, err))
+	return stream, err
+}
@@ -0,0 +1,155 @@
+package clients
+
+import (
+	"context"
+	"contracts/rpcerr"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	b := newBreaker("profiles", time.Second)
+
+	for i := 0; i < failureThreshold; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("call %d rejected before the threshold: %v", i, err)
+		}
+		b.record(callFailed)
+	}
+
+	err := b.allow()
+	if status.Code(err) != codes.Unavailable || rpcerr.Reason(err) != ReasonCircuitOpen {
+		t.Fatalf("got %v from an open circuit, want a %s error", err, ReasonCircuitOpen)
+	}
+	if !Unavailable(err) {
+		t.Error("errors of an open circuit should be unavailable")
+	}
+
+	// once open for long enough, a single probe is let through
+	b.openUntil = time.Now()
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("a second call was let through while probing")
+	}
+
+	// a failed probe opens the circuit again
+	b.record(callFailed)
+	if err := b.allow(); err == nil {
+		t.Fatal("circuit closed after a failed probe")
+	}
+
+	// a successful probe closes it
+	b.openUntil = time.Now()
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	b.record(callSucceeded)
+	if err := b.allow(); err != nil {
+		t.Fatalf("circuit still open after a successful probe: %v", err)
+	}
+}
+
+func TestBreakerAbandonedProbe(t *testing.T) {
+	b := newBreaker("profiles", time.Second)
+	for i := 0; i < failureThreshold; i++ {
+		b.record(callFailed)
+	}
+	openUntil := time.Now()
+	b.openUntil = openUntil
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+
+	// the caller gave up on the probe, the next call probes again
+	b.record(callAbandoned)
+	if b.failures != failureThreshold || !b.openUntil.Equal(openUntil) {
+		t.Fatalf("got %d failures open until %v, want the circuit unchanged", b.failures, b.openUntil)
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected after an abandoned one: %v", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("a second call was let through while probing")
+	}
+}
+
+func TestBreakerOutcome(t *testing.T) {
+	b := newBreaker("profiles", time.Second)
+	start := time.Now()
+	short, cancel := context.WithDeadline(context.Background(), start.Add(100*time.Millisecond))
+	defer cancel()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	long, cancel := context.WithDeadline(context.Background(), start.Add(time.Minute))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want outcome
+	}{
+		{name: "unavailable", ctx: context.Background(), err: status.Error(codes.Unavailable, "connection refused"), want: callFailed},
+		{name: "service config timeout", ctx: context.Background(), err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), want: callFailed},
+		{name: "caller deadline past the timeout", ctx: long, err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), want: callFailed},
+		{name: "caller deadline", ctx: short, err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), want: callAbandoned},
+		{name: "caller canceled", ctx: canceled, err: status.Error(codes.Canceled, "context canceled"), want: callAbandoned},
+		{name: "error after the caller canceled", ctx: canceled, err: status.Error(codes.Internal, "stream reset"), want: callAbandoned},
+		{name: "service error", ctx: context.Background(), err: status.Error(codes.NotFound, "profile not found"), want: callSucceeded},
+		{name: "success", ctx: context.Background(), want: callSucceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.outcome(tt.ctx, start, tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceConfigRetriesIdempotentMethods(t *testing.T) {
+	var config struct {
+		MethodConfig []struct {
+			Name []struct {
+				Service string `json:"service"`
+				Method  string `json:"method"`
+			} `json:"name"`
+			Timeout     string          `json:"timeout"`
+			RetryPolicy json.RawMessage `json:"retryPolicy"`
+		} `json:"methodConfig"`
+	}
+	if err := json.Unmarshal([]byte(serviceConfig(2*time.Second)), &config); err != nil {
+		t.Fatal(err)
+	}
+
+	retried := map[string]bool{}
+	for _, mc := range config.MethodConfig {
+		if mc.Timeout != "2s" {
+			t.Errorf("got timeout %q, want 2s", mc.Timeout)
+		}
+		if mc.RetryPolicy == nil {
+			continue
+		}
+		for _, name := range mc.Name {
+			retried[name.Service+"/"+name.Method] = true
+		}
+	}
+
+	for method, want := range map[string]bool{
+		"profile.ProfileService/GetProfile":    true,
+		"user_grpc.UserService/IsValidToken":   true,
+		"profile.ProfileService/UpdateProfile": false,
+		"logs_grpc.LogService/AddLog":          false,
+	} {
+		if retried[method] != want {
+			t.Errorf("%s retried: got %v, want %v", method, retried[method], want)
+		}
+	}
+}
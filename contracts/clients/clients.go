@@ -0,0 +1,112 @@
+// Package clients dials the services of the dating app, so every service
+// talks to the others with the same connection options.
+package clients
+
+import (
+	"contracts/pb"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Dial opens a client connection to the service at addr. Connections use
+// TLS with the system roots, unless GRPC_INSECURE is set to true for
+// plaintext setups such as docker compose.
+//
+// Calls time out after GRPC_TIMEOUT, 5s by default, unless their context
+// has a shorter deadline, and idempotent RPCs are retried while the
+// service is unavailable, see serviceConfig. Each connection has its own
+// circuit breaker, running after the interceptors of opts.
+func Dial(addr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	cred, err := transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := callTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	b := newBreaker(addr, timeout)
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(cred),
+		grpc.WithDefaultServiceConfig(serviceConfig(timeout)),
+	}, opts...)
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(b.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(b.streamInterceptor),
+	)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+func transportCredentials() (credentials.TransportCredentials, error) {
+	if plaintext, _ := strconv.ParseBool(os.Getenv("GRPC_INSECURE")); plaintext {
+		return insecure.NewCredentials(), nil
+	}
+
+	systemRoots, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certs: %w", err)
+	}
+	return credentials.NewTLS(&tls.Config{
+		RootCAs: systemRoots,
+	}), nil
+}
+
+func NewUserClient(addr string, opts ...grpc.DialOption) (pb.UserServiceClient, error) {
+	conn, err := Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewUserServiceClient(conn), nil
+}
+
+func NewProfileClient(addr string, opts ...grpc.DialOption) (pb.ProfileServiceClient, error) {
+	conn, err := Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewProfileServiceClient(conn), nil
+}
+
+func NewSwipeClient(addr string, opts ...grpc.DialOption) (pb.SwipeServiceClient, error) {
+	conn, err := Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewSwipeServiceClient(conn), nil
+}
+
+func NewMatchClient(addr string, opts ...grpc.DialOption) (pb.MatchServiceClient, error) {
+	conn, err := Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewMatchServiceClient(conn), nil
+}
+
+func NewPaymentClient(addr string, opts ...grpc.DialOption) (pb.SubPaymentClient, error) {
+	conn, err := Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewSubPaymentClient(conn), nil
+}
+
+func NewLogClient(addr string, opts ...grpc.DialOption) (pb.LogServiceClient, error) {
+	conn, err := Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewLogServiceClient(conn), nil
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"contracts/pb"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// defaultTimeout bounds the calls made without a shorter deadline, so a
+// slow service can't hang its callers. Override it with GRPC_TIMEOUT.
+const defaultTimeout = 5 * time.Second
+
+// retryPolicy of the RPCs safe to retry, attempts are only retried when the
+// service couldn't be reached.
+var retryPolicy = map[string]any{
+	"maxAttempts":          3,
+	"initialBackoff":       "0.1s",
+	"maxBackoff":           "1s",
+	"backoffMultiplier":    2,
+	"retryableStatusCodes": []string{"UNAVAILABLE"},
+}
+
+// files declare the services dialed through this package.
+var files = []protoreflect.FileDescriptor{
+	pb.File_logs_proto,
+	pb.File_match_proto,
+	pb.File_profile_proto,
+	pb.File_subs_payment_proto,
+	pb.File_swipe_proto,
+	pb.File_user_proto,
+}
+
+func callTimeout() (time.Duration, error) {
+	s := os.Getenv("GRPC_TIMEOUT")
+	if s == "" {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid GRPC_TIMEOUT %q, want a positive duration", s)
+	}
+	return timeout, nil
+}
+
+// serviceConfig returns the default service config of connections. Every
+// call times out after timeout, and the RPCs declared with
+// "option idempotency_level = IDEMPOTENT;" (or NO_SIDE_EFFECTS) are retried.
+func serviceConfig(timeout time.Duration) string {
+	seconds := fmt.Sprintf("%gs", timeout.Seconds())
+	idempotent := []map[string]string{}
+	for _, fd := range files {
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				opts, _ := md.Options().(*descriptorpb.MethodOptions)
+				if opts.GetIdempotencyLevel() == descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN {
+					continue
+				}
+				idempotent = append(idempotent, map[string]string{
+					"service": string(sd.FullName()),
+					"method":  string(md.Name()),
+				})
+			}
+		}
+	}
+
+	config, _ := json.Marshal(map[string]any{
+		"methodConfig": []map[string]any{
+			{"name": []map[string]string{{}}, "timeout": seconds},
+			{"name": idempotent, "timeout": seconds, "retryPolicy": retryPolicy},
+		},
+	})
+	return string(config)
+}
@@ -0,0 +1,53 @@
+// Command protocheck reports copies of the proto files and generated code
+// that drifted from the canonical contracts, and breaking changes of the
+// canonical contracts against the committed baseline. Run it from the
+// contracts module:
+//
+//	go run ./cmd/protocheck
+//	go run ./cmd/protocheck -update  # accept the current contracts as baseline
+package main
+
+import (
+	"contracts/compat"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	root := flag.String("root", "..", "repository root searched for proto files and generated code")
+	canonical := flag.String("proto", "proto", "directory of the canonical proto files")
+	baseline := flag.String("baseline", "compat/baseline.json", "committed schema of the released contracts")
+	update := flag.Bool("update", false, "write the canonical contracts to the baseline instead of checking")
+	flag.Parse()
+
+	if *update {
+		schema, err := compat.ParseProtos(*canonical)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := compat.WriteBaseline(*baseline, schema); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("baseline written to %s", *baseline)
+		return
+	}
+
+	problems, err := compat.Check(compat.Config{
+		Root:      *root,
+		Canonical: *canonical,
+		Baseline:  *baseline,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		log.Printf("%d contract problems found", len(problems))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,106 @@
+package compat
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Config locates the contracts checked by Check.
+type Config struct {
+	// Root is searched for copies of the proto files and generated code
+	Root string
+	// Canonical is the directory of the canonical proto files
+	Canonical string
+	// Baseline is the committed schema of the released contracts
+	Baseline string
+}
+
+// Check compares every proto file and every generated descriptor below
+// Root with the canonical contracts, and the canonical contracts with the
+// baseline.
+func Check(cfg Config) ([]Problem, error) {
+	canonical, err := ParseProtos(cfg.Canonical)
+	if err != nil {
+		return nil, err
+	}
+	canonicalDir, err := filepath.Abs(cfg.Canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []Problem{}
+
+	protoDirs, err := findDirs(cfg.Root, ".proto")
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range protoDirs {
+		if abs, _ := filepath.Abs(dir); abs == canonicalDir {
+			continue
+		}
+
+		copies, err := ParseProtos(dir)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, compare(canonical, copies, func(path string) string {
+			return relative(cfg.Root, filepath.Join(dir, path))
+		})...)
+	}
+
+	generatedDirs, err := findDirs(cfg.Root, ".pb.go")
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range generatedDirs {
+		generated, err := ParseGenerated(dir)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, compare(canonical, generated, func(path string) string {
+			return fmt.Sprintf("%s (generated from %s)", relative(cfg.Root, dir), path)
+		})...)
+	}
+
+	baseline, err := LoadBaseline(cfg.Baseline)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range Breaking(baseline, canonical) {
+		p.Path = fmt.Sprintf("%s (breaking change)", relative(cfg.Root, filepath.Join(cfg.Canonical, p.Path)))
+		problems = append(problems, p)
+	}
+
+	return problems, nil
+}
+
+// compare reports the files of schema that differ from their canonical
+// version, at the location returned for their path.
+func compare(canonical, schema Schema, location func(path string) string) []Problem {
+	problems := []Problem{}
+	for _, path := range sortedKeys(schema) {
+		location := location(path)
+		want, ok := canonical[path]
+		if !ok {
+			problems = append(problems, Problem{location, "has no canonical contract"})
+			continue
+		}
+		problems = append(problems, Mismatches(location, want, schema[path])...)
+	}
+	return problems
+}
+
+func relative(root, path string) string {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(absRoot, absPath); err == nil {
+		return rel
+	}
+	return path
+}
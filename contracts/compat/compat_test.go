@@ -0,0 +1,40 @@
+package compat
+
+import "testing"
+
+// TestContracts fails the build when a proto copy or generated code drifts
+// from the canonical contracts, or when they break the baseline.
+func TestContracts(t *testing.T) {
+	problems, err := Check(Config{
+		Root:      "../..",
+		Canonical: "../proto",
+		Baseline:  "baseline.json",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+func TestRenamedField(t *testing.T) {
+	file := func(name string) File {
+		return File{
+			Package: "profile",
+			Messages: map[string]Message{
+				"profile.GetProfileRequest": {Fields: map[int32]Field{1: {Name: name, Type: "uint32"}}},
+			},
+		}
+	}
+
+	mismatches := Mismatches("profile.proto", file("id"), file("user_id"))
+	if len(mismatches) != 1 {
+		t.Fatalf("got %d mismatches, want 1: %v", len(mismatches), mismatches)
+	}
+
+	breaking := Breaking(Schema{"profile.proto": file("id")}, Schema{"profile.proto": file("user_id")})
+	if len(breaking) != 1 {
+		t.Fatalf("got %d breaking changes, want 1: %v", len(breaking), breaking)
+	}
+}
@@ -0,0 +1,172 @@
+package compat
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Problem is a difference between two versions of a contract, reported
+// against Path, the proto file or generated code it was found in.
+type Problem struct {
+	Path   string
+	Detail string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Detail)
+}
+
+// change is a single element of the old contract that is missing from, or
+// differs in, the new one.
+type change struct {
+	subject  string
+	missing  bool
+	property string
+	old, new string
+}
+
+// Breaking lists the changes from old to new that break clients or servers
+// still built against old. Additions are not breaking, and neither is
+// adding or dropping the optional keyword of a proto3 field.
+func Breaking(old, new Schema) []Problem {
+	problems := []Problem{}
+	for _, path := range sortedKeys(old) {
+		newFile, ok := new[path]
+		if !ok {
+			problems = append(problems, Problem{path, "file was removed"})
+			continue
+		}
+
+		for _, c := range changes(old[path], newFile) {
+			switch {
+			case c.missing:
+				problems = append(problems, Problem{path, fmt.Sprintf("%s was removed", c.subject)})
+			case c.property == "label" && (c.old == "optional" && c.new == "" || c.old == "" && c.new == "optional"):
+				// explicit presence doesn't change the encoding
+			default:
+				problems = append(problems, Problem{path, fmt.Sprintf("%s changed %s from %s to %s", c.subject, c.property, quote(c.old), quote(c.new))})
+			}
+		}
+	}
+	return problems
+}
+
+// Mismatches lists every difference of got from want, including additions,
+// since two copies of a contract must agree exactly on the wire. path is
+// reported as the location of got.
+func Mismatches(path string, want, got File) []Problem {
+	problems := []Problem{}
+	for _, c := range changes(want, got) {
+		if c.missing {
+			problems = append(problems, Problem{path, fmt.Sprintf("%s is missing", c.subject)})
+			continue
+		}
+		problems = append(problems, Problem{path, fmt.Sprintf("%s has %s %s, want %s", c.subject, c.property, quote(c.new), quote(c.old))})
+	}
+	for _, c := range changes(got, want) {
+		if c.missing {
+			problems = append(problems, Problem{path, fmt.Sprintf("%s is not in the canonical contract", c.subject)})
+		}
+	}
+	return problems
+}
+
+// changes compares the elements of old with those of new.
+func changes(old, new File) []change {
+	result := []change{}
+	if old.Package != new.Package {
+		result = append(result, change{subject: "file", property: "package", old: old.Package, new: new.Package})
+	}
+
+	for _, name := range sortedKeys(old.Messages) {
+		subject := "message " + name
+		newMessage, ok := new.Messages[name]
+		if !ok {
+			result = append(result, change{subject: subject, missing: true})
+			continue
+		}
+
+		oldFields := old.Messages[name].Fields
+		for _, number := range sortedKeys(oldFields) {
+			subject := fmt.Sprintf("field %d of %s", number, name)
+			oldField := oldFields[number]
+			newField, ok := newMessage.Fields[number]
+			if !ok {
+				result = append(result, change{subject: subject, missing: true})
+				continue
+			}
+			result = appendIfChanged(result, subject, "name", oldField.Name, newField.Name)
+			result = appendIfChanged(result, subject, "type", oldField.Type, newField.Type)
+			result = appendIfChanged(result, subject, "label", oldField.Label, newField.Label)
+		}
+	}
+
+	for _, name := range sortedKeys(old.Enums) {
+		subject := "enum " + name
+		newEnum, ok := new.Enums[name]
+		if !ok {
+			result = append(result, change{subject: subject, missing: true})
+			continue
+		}
+
+		oldValues := old.Enums[name].Values
+		for _, number := range sortedKeys(oldValues) {
+			subject := fmt.Sprintf("value %d of %s", number, name)
+			newValue, ok := newEnum.Values[number]
+			if !ok {
+				result = append(result, change{subject: subject, missing: true})
+				continue
+			}
+			result = appendIfChanged(result, subject, "name", oldValues[number], newValue)
+		}
+	}
+
+	for _, name := range sortedKeys(old.Services) {
+		subject := "service " + name
+		newService, ok := new.Services[name]
+		if !ok {
+			result = append(result, change{subject: subject, missing: true})
+			continue
+		}
+
+		oldMethods := old.Services[name].Methods
+		for _, method := range sortedKeys(oldMethods) {
+			subject := fmt.Sprintf("rpc %s.%s", name, method)
+			oldMethod := oldMethods[method]
+			newMethod, ok := newService.Methods[method]
+			if !ok {
+				result = append(result, change{subject: subject, missing: true})
+				continue
+			}
+			result = appendIfChanged(result, subject, "input", oldMethod.Input, newMethod.Input)
+			result = appendIfChanged(result, subject, "output", oldMethod.Output, newMethod.Output)
+			result = appendIfChanged(result, subject, "client streaming", fmt.Sprint(oldMethod.ClientStreaming), fmt.Sprint(newMethod.ClientStreaming))
+			result = appendIfChanged(result, subject, "server streaming", fmt.Sprint(oldMethod.ServerStreaming), fmt.Sprint(newMethod.ServerStreaming))
+		}
+	}
+
+	return result
+}
+
+func appendIfChanged(result []change, subject, property, old, new string) []change {
+	if old == new {
+		return result
+	}
+	return append(result, change{subject: subject, property: property, old: old, new: new})
+}
+
+func quote(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+func sortedKeys[K string | int32, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
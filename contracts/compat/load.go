@@ -0,0 +1,195 @@
+package compat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ParseProtos parses the .proto files of dir, resolving imports against dir,
+// the well-known types and the third-party protos linked into this binary,
+// such as google/api/annotations.proto.
+func ParseProtos(dir string) (Schema, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.proto"))
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range paths {
+		paths[i] = filepath.Base(path)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protocompile.CompositeResolver{
+			&protocompile.SourceResolver{ImportPaths: []string{dir}},
+			protocompile.ResolverFunc(linkedFile),
+		}),
+	}
+	files, err := compiler.Compile(context.Background(), paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := Schema{}
+	for _, file := range files {
+		schema[file.Path()] = FromDescriptor(protodesc.ToFileDescriptorProto(file))
+	}
+	return schema, nil
+}
+
+func linkedFile(path string) (protocompile.SearchResult, error) {
+	fd, err := protoregistry.GlobalFiles.FindFileByPath(path)
+	if err != nil {
+		return protocompile.SearchResult{}, err
+	}
+	return protocompile.SearchResult{Desc: fd}, nil
+}
+
+// ParseGenerated extracts the wire descriptors embedded in the *.pb.go files
+// of dir, keyed by the path of the proto file they were generated from.
+func ParseGenerated(dir string) (Schema, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pb.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	schema := Schema{}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value, ok := spec.(*ast.ValueSpec)
+				if !ok || len(value.Names) != 1 || len(value.Values) != 1 || !strings.HasSuffix(value.Names[0].Name, "_rawDesc") {
+					continue
+				}
+
+				raw, err := rawBytes(value.Values[0])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s: %w", path, value.Names[0].Name, err)
+				}
+				fdp := &descriptorpb.FileDescriptorProto{}
+				if err := proto.Unmarshal(raw, fdp); err != nil {
+					return nil, fmt.Errorf("%s: %s: %w", path, value.Names[0].Name, err)
+				}
+				schema[fdp.GetName()] = FromDescriptor(fdp)
+			}
+		}
+	}
+	return schema, nil
+}
+
+// rawBytes evaluates the literal holding a raw descriptor, a []byte literal
+// in older protoc-gen-go versions and a string constant in newer ones.
+func rawBytes(expr ast.Expr) ([]byte, error) {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		raw := make([]byte, 0, len(e.Elts))
+		for _, elt := range e.Elts {
+			lit, ok := elt.(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT {
+				return nil, fmt.Errorf("unexpected element %T in raw descriptor", elt)
+			}
+			b, err := strconv.ParseUint(lit.Value, 0, 8)
+			if err != nil {
+				return nil, err
+			}
+			raw = append(raw, byte(b))
+		}
+		return raw, nil
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return nil, fmt.Errorf("unexpected %s literal in raw descriptor", e.Kind)
+		}
+		s, err := strconv.Unquote(e.Value)
+		return []byte(s), err
+	case *ast.BinaryExpr:
+		x, err := rawBytes(e.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := rawBytes(e.Y)
+		if err != nil {
+			return nil, err
+		}
+		return append(x, y...), nil
+	case *ast.CallExpr:
+		// string([]byte{...}) or []byte("...")
+		if len(e.Args) == 1 {
+			return rawBytes(e.Args[0])
+		}
+	case *ast.ParenExpr:
+		return rawBytes(e.X)
+	}
+	return nil, fmt.Errorf("unexpected expression %T in raw descriptor", expr)
+}
+
+// findDirs returns every directory below root holding a file whose name ends
+// with suffix.
+func findDirs(root, suffix string) ([]string, error) {
+	seen := map[string]bool{}
+	dirs := []string{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "node_modules", "vendor", "testdata", "third_party":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		if strings.HasSuffix(d.Name(), suffix) && !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+func LoadBaseline(path string) (Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := Schema{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schema, nil
+}
+
+func WriteBaseline(path string, schema Schema) error {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,133 @@
+// Package compat checks that every copy of the protobuf contracts, the code
+// generated from them and the committed baseline agree on the wire.
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Schema is the wire contract of a set of proto files, keyed by file path
+// (e.g. "profile.proto").
+type Schema map[string]File
+
+type File struct {
+	Package  string             `json:"package"`
+	Messages map[string]Message `json:"messages"`
+	Enums    map[string]Enum    `json:"enums,omitempty"`
+	Services map[string]Service `json:"services,omitempty"`
+}
+
+type Message struct {
+	Fields map[int32]Field `json:"fields"`
+}
+
+// Field is a message field. Type is the scalar kind or the full name of the
+// message or enum, Label is "repeated", "optional" or empty.
+type Field struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Label string `json:"label,omitempty"`
+}
+
+type Enum struct {
+	Values map[int32]string `json:"values"`
+}
+
+type Service struct {
+	Methods map[string]Method `json:"methods"`
+}
+
+type Method struct {
+	Input           string `json:"input"`
+	Output          string `json:"output"`
+	ClientStreaming bool   `json:"client_streaming,omitempty"`
+	ServerStreaming bool   `json:"server_streaming,omitempty"`
+}
+
+// FromDescriptor extracts the wire contract of a file descriptor, as
+// produced by the proto compiler or embedded in generated code.
+func FromDescriptor(fdp *descriptorpb.FileDescriptorProto) File {
+	file := File{
+		Package:  fdp.GetPackage(),
+		Messages: map[string]Message{},
+		Enums:    map[string]Enum{},
+		Services: map[string]Service{},
+	}
+
+	for _, m := range fdp.GetMessageType() {
+		file.addMessage(fdp.GetPackage(), m)
+	}
+	for _, e := range fdp.GetEnumType() {
+		file.addEnum(fdp.GetPackage(), e)
+	}
+	for _, s := range fdp.GetService() {
+		service := Service{Methods: map[string]Method{}}
+		for _, m := range s.GetMethod() {
+			service.Methods[m.GetName()] = Method{
+				Input:           strings.TrimPrefix(m.GetInputType(), "."),
+				Output:          strings.TrimPrefix(m.GetOutputType(), "."),
+				ClientStreaming: m.GetClientStreaming(),
+				ServerStreaming: m.GetServerStreaming(),
+			}
+		}
+		file.Services[fullName(fdp.GetPackage(), s.GetName())] = service
+	}
+
+	return file
+}
+
+func (f File) addMessage(scope string, m *descriptorpb.DescriptorProto) {
+	name := fullName(scope, m.GetName())
+
+	// map fields are repeated fields of a generated nested entry message
+	entries := map[string]*descriptorpb.DescriptorProto{}
+	for _, nested := range m.GetNestedType() {
+		if nested.GetOptions().GetMapEntry() {
+			entries[name+"."+nested.GetName()] = nested
+			continue
+		}
+		f.addMessage(name, nested)
+	}
+	for _, e := range m.GetEnumType() {
+		f.addEnum(name, e)
+	}
+
+	message := Message{Fields: map[int32]Field{}}
+	for _, fd := range m.GetField() {
+		field := Field{Name: fd.GetName(), Type: fieldType(fd)}
+		if entry, ok := entries[field.Type]; ok {
+			field.Type = fmt.Sprintf("map<%s, %s>", fieldType(entry.GetField()[0]), fieldType(entry.GetField()[1]))
+		} else if fd.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			field.Label = "repeated"
+		} else if fd.GetProto3Optional() {
+			field.Label = "optional"
+		}
+		message.Fields[fd.GetNumber()] = field
+	}
+	f.Messages[name] = message
+}
+
+func (f File) addEnum(scope string, e *descriptorpb.EnumDescriptorProto) {
+	enum := Enum{Values: map[int32]string{}}
+	for _, v := range e.GetValue() {
+		enum.Values[v.GetNumber()] = v.GetName()
+	}
+	f.Enums[fullName(scope, e.GetName())] = enum
+}
+
+func fieldType(fd *descriptorpb.FieldDescriptorProto) string {
+	if name := fd.GetTypeName(); name != "" {
+		return strings.TrimPrefix(name, ".")
+	}
+	return strings.ToLower(strings.TrimPrefix(fd.GetType().String(), "TYPE_"))
+}
+
+func fullName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
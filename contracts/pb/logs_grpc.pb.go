@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	LogService_AddLog_FullMethodName     = "/logs_grpc.LogService/AddLog"
+	LogService_AddLogs_FullMethodName    = "/logs_grpc.LogService/AddLogs"
 	LogService_GetLogs_FullMethodName    = "/logs_grpc.LogService/GetLogs"
 	LogService_StreamLogs_FullMethodName = "/logs_grpc.LogService/StreamLogs"
 )
@@ -30,8 +31,10 @@ const (
 //
 // The log service definition
 type LogServiceClient interface {
-	// Add a new activity log
+	// Add a new activity log, only the services may, with the service_token metadata
 	AddLog(ctx context.Context, in *AddLogRequest, opts ...grpc.CallOption) (*AddLogResponse, error)
+	// Add a batch of activity logs at once, only the services may, with the service_token metadata
+	AddLogs(ctx context.Context, in *AddLogsRequest, opts ...grpc.CallOption) (*AddLogsResponse, error)
 	// Get activity logs for a specific user
 	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error)
 	// Stream activity logs in real-time
@@ -56,6 +59,16 @@ func (c *logServiceClient) AddLog(ctx context.Context, in *AddLogRequest, opts .
 	return out, nil
 }
 
+func (c *logServiceClient) AddLogs(ctx context.Context, in *AddLogsRequest, opts ...grpc.CallOption) (*AddLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddLogsResponse)
+	err := c.cc.Invoke(ctx, LogService_AddLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *logServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetLogsResponse)
@@ -91,8 +104,10 @@ type LogService_StreamLogsClient = grpc.ServerStreamingClient[StreamLogsResponse
 //
 // The log service definition
 type LogServiceServer interface {
-	// Add a new activity log
+	// Add a new activity log, only the services may, with the service_token metadata
 	AddLog(context.Context, *AddLogRequest) (*AddLogResponse, error)
+	// Add a batch of activity logs at once, only the services may, with the service_token metadata
+	AddLogs(context.Context, *AddLogsRequest) (*AddLogsResponse, error)
 	// Get activity logs for a specific user
 	GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error)
 	// Stream activity logs in real-time
@@ -110,6 +125,9 @@ type UnimplementedLogServiceServer struct{}
 func (UnimplementedLogServiceServer) AddLog(context.Context, *AddLogRequest) (*AddLogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddLog not implemented")
 }
+func (UnimplementedLogServiceServer) AddLogs(context.Context, *AddLogsRequest) (*AddLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLogs not implemented")
+}
 func (UnimplementedLogServiceServer) GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
 }
@@ -155,6 +173,24 @@ func _LogService_AddLog_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogService_AddLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogServiceServer).AddLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogService_AddLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogServiceServer).AddLogs(ctx, req.(*AddLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LogService_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLogsRequest)
 	if err := dec(in); err != nil {
@@ -195,6 +231,10 @@ var LogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddLog",
 			Handler:    _LogService_AddLog_Handler,
 		},
+		{
+			MethodName: "AddLogs",
+			Handler:    _LogService_AddLogs_Handler,
+		},
 		{
 			MethodName: "GetLogs",
 			Handler:    _LogService_GetLogs_Handler,
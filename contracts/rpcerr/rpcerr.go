@@ -0,0 +1,132 @@
+// Package rpcerr builds gRPC errors carrying structured details for the
+// services, and reads them back in the api-gateway.
+package rpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain of the ErrorInfo details attached by WithReason.
+const Domain = "dating-apps"
+
+// FieldViolation describes why a field of a request is invalid, Description
+// is a complete sentence such as "age must be at least 18".
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Invalid returns an InvalidArgument error listing the invalid fields of a
+// request.
+func Invalid(violations ...FieldViolation) error {
+	parts := make([]string, 0, len(violations))
+	details := &errdetails.BadRequest{}
+	for _, v := range violations {
+		parts = append(parts, v.Description)
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	return withDetails(status.New(codes.InvalidArgument, strings.Join(parts, "; ")), details)
+}
+
+// InvalidField returns an InvalidArgument error for a single field.
+func InvalidField(field, description string) error {
+	return Invalid(FieldViolation{Field: field, Description: description})
+}
+
+// Required returns the InvalidArgument error of a missing field.
+func Required(field string) error {
+	return InvalidField(field, fmt.Sprintf("%s is required", field))
+}
+
+// WithReason returns an error of code carrying a machine-readable reason in
+// UPPER_SNAKE_CASE, e.g. "ALREADY_SWIPED", for clients to tell apart errors
+// sharing a code.
+func WithReason(code codes.Code, reason string, format string, args ...any) error {
+	return withDetails(status.Newf(code, format, args...), &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: Domain,
+	})
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// Reason returns the reason attached to err by WithReason, if any.
+func Reason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+// FieldViolations returns the invalid fields attached to err by Invalid.
+func FieldViolations(err error) []FieldViolation {
+	violations := []FieldViolation{}
+	for _, detail := range status.Convert(err).Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range badRequest.GetFieldViolations() {
+			violations = append(violations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+		}
+	}
+	return violations
+}
+
+// UnaryServerInterceptor makes sure every error leaving a service is a gRPC
+// status. Plain errors, such as database failures, are logged and replaced
+// by an Internal error so their text doesn't leak to clients.
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(info.FullMethod, err)
+	}
+	return res, nil
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if err != nil {
+		return toStatus(info.FullMethod, err)
+	}
+	return nil
+}
+
+func toStatus(method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	log.Printf("%s: %s", method, err.Error())
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,191 @@
+// Package validate checks protobuf messages against the validate.rules
+// options of their fields, declared in proto/validate.proto.
+package validate
+
+import (
+	"contracts/pb"
+	"contracts/rpcerr"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Message returns an InvalidArgument error listing every field of m that
+// breaks its rules, or nil.
+func Message(m proto.Message) error {
+	if violations := Violations(m); len(violations) > 0 {
+		return rpcerr.Invalid(violations...)
+	}
+	return nil
+}
+
+// Violations lists every field of m, and of the messages it holds, that
+// breaks its rules. Fields are named by their path, e.g. "photos[1]".
+func Violations(m proto.Message) []rpcerr.FieldViolation {
+	c := &checker{violations: []rpcerr.FieldViolation{}}
+	c.message(m.ProtoReflect(), "")
+	return c.violations
+}
+
+type checker struct {
+	violations []rpcerr.FieldViolation
+}
+
+func (c *checker) add(field, format string, args ...any) {
+	c.violations = append(c.violations, rpcerr.FieldViolation{
+		Field:       field,
+		Description: field + " " + fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) message(m protoreflect.Message, prefix string) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		rules := Rules(fd)
+
+		// Has reports zero proto3 scalars and empty lists as unset
+		if !m.Has(fd) {
+			if rules.GetRequired() {
+				c.add(path, "is required")
+			}
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			list := m.Get(fd).List()
+			if rules.MinItems != nil && list.Len() < int(rules.GetMinItems()) {
+				c.add(path, "must have at least %d items", rules.GetMinItems())
+			}
+			if rules.MaxItems != nil && list.Len() > int(rules.GetMaxItems()) {
+				c.add(path, "must have at most %d items", rules.GetMaxItems())
+			}
+			for j := 0; j < list.Len(); j++ {
+				c.value(fd, rules, fmt.Sprintf("%s[%d]", path, j), list.Get(j))
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				m.Get(fd).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+					c.message(value.Message(), fmt.Sprintf("%s[%v].", path, key.Interface()))
+					return true
+				})
+			}
+		default:
+			c.value(fd, rules, path, m.Get(fd))
+		}
+	}
+}
+
+func (c *checker) value(fd protoreflect.FieldDescriptor, rules *pb.FieldRules, path string, value protoreflect.Value) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		c.message(value.Message(), path+".")
+	case protoreflect.StringKind:
+		c.string(rules, path, value.String())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		c.number(rules, path, float64(value.Int()))
+	case protoreflect.EnumKind:
+		c.number(rules, path, float64(value.Enum()))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		c.number(rules, path, float64(value.Uint()))
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		c.number(rules, path, value.Float())
+	}
+}
+
+func (c *checker) number(rules *pb.FieldRules, path string, n float64) {
+	if rules.Min != nil && n < float64(rules.GetMin()) {
+		c.add(path, "must be at least %d", rules.GetMin())
+	}
+	if rules.Max != nil && n > float64(rules.GetMax()) {
+		c.add(path, "must be at most %d", rules.GetMax())
+	}
+	if math.IsNaN(n) && (rules.Min != nil || rules.Max != nil) {
+		c.add(path, "must be a number")
+	}
+}
+
+func (c *checker) string(rules *pb.FieldRules, path, s string) {
+	length := utf8.RuneCountInString(s)
+	if rules.MinLen != nil && length < int(rules.GetMinLen()) {
+		c.add(path, "must be at least %d characters", rules.GetMinLen())
+	}
+	if rules.MaxLen != nil && length > int(rules.GetMaxLen()) {
+		c.add(path, "must be at most %d characters", rules.GetMaxLen())
+	}
+	if in := rules.GetIn(); len(in) > 0 && !contains(in, s) {
+		c.add(path, "must be one of %s", strings.Join(in, ", "))
+	}
+	if pattern := rules.GetPattern(); pattern != "" && !compile(pattern).MatchString(s) {
+		c.add(path, "must match %s", pattern)
+	}
+	if rules.GetEmail() && !isEmail(s) {
+		c.add(path, "must be a valid email address")
+	}
+	if rules.GetUrl() && !isURL(s) {
+		c.add(path, "must be a valid http or https URL")
+	}
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmail accepts bare addresses only, "Name <user@example.com>" is rejected.
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// rules and patterns are looked up once per field
+var (
+	rulesCache   sync.Map // protoreflect.FullName -> *pb.FieldRules
+	patternCache sync.Map // string -> *regexp.Regexp
+)
+
+// Rules returns the rules of fd, empty ones when it has none. They are
+// shared between callers and must not be modified.
+func Rules(fd protoreflect.FieldDescriptor) *pb.FieldRules {
+	if cached, ok := rulesCache.Load(fd.FullName()); ok {
+		return cached.(*pb.FieldRules)
+	}
+
+	rules := &pb.FieldRules{}
+	if opts := fd.Options(); opts != nil && proto.HasExtension(opts, pb.E_Rules) {
+		rules = proto.GetExtension(opts, pb.E_Rules).(*pb.FieldRules)
+	}
+	rulesCache.Store(fd.FullName(), rules)
+	return rules
+}
+
+// compile panics on an invalid pattern, a mistake in a proto file rather
+// than in a request.
+func compile(pattern string) *regexp.Regexp {
+	if cached, ok := patternCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patternCache.Store(pattern, re)
+	return re
+}
@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"contracts/pb"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestViolations(t *testing.T) {
+	tests := []struct {
+		name string
+		req  proto.Message
+		want []string
+	}{
+		{
+			name: "valid",
+			req:  &pb.RecordSwipeRequest{SwiperUserId: 1, SwipedProfileUserId: 2, Action: "like"},
+			want: []string{},
+		},
+		{
+			name: "every violation at once",
+			req:  &pb.CreateProfileRequest{Age: -1, Photos: []string{"https://example.com/a.jpg", "a.jpg"}},
+			want: []string{"age", "bio", "photos[1]"},
+		},
+		{
+			name: "value not allowed",
+			req:  &pb.RecordSwipeRequest{SwiperUserId: 1, SwipedProfileUserId: 2, Action: "superlike"},
+			want: []string{"action"},
+		},
+		{
+			name: "unset optional fields are skipped",
+			req:  &pb.UpdateProfileRequest{Id: 1},
+			want: []string{},
+		},
+		{
+			name: "set optional fields are checked",
+			req:  &pb.UpdateProfileRequest{Id: 1, Age: proto.Int32(0)},
+			want: []string{"age"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := []string{}
+			for _, v := range Violations(tt.req) {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.want) {
+				t.Errorf("got violations of %v, want %v", fields, tt.want)
+			}
+		})
+	}
+}
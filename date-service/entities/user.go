@@ -1,9 +1,24 @@
 package entities
 
+import "contracts/auth"
+
+// Roles a user can have, as issued by users-service.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
 type User struct {
 	ID         uint
 	Username   string
 	Email      string
 	IsPremium  bool
 	IsVerified bool
+	Role       string
+}
+
+// Identity tells the auth interceptor who the user is.
+func (u *User) Identity() auth.Identity {
+	return auth.Identity{ID: u.ID, Role: u.Role, IsPremium: u.IsPremium}
 }
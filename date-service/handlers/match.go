@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"contracts/pb"
+	"date-service/models"
+	"date-service/services"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MatchHandler struct {
+	pb.UnimplementedMatchServiceServer
+	db          *gorm.DB
+	userService services.UserService
+}
+
+func NewMatchHandler(db *gorm.DB, userService services.UserService) *MatchHandler {
+	return &MatchHandler{
+		db:          db,
+		userService: userService,
+	}
+}
+
+func toPbMatch(match models.Match) *pb.Match {
+	return &pb.Match{
+		Id:        fmt.Sprintf("%d", match.ID),
+		User1Id:   uint32(match.User1ID),
+		User2Id:   uint32(match.User2ID),
+		MatchedAt: match.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (m *MatchHandler) CheckMatch(ctx context.Context, req *pb.CheckMatchRequest) (*pb.CheckMatchResponse, error) {
+	// a match can be recorded in either direction
+	var match models.Match
+	err := m.db.Where(
+		"(user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)",
+		req.User1Id, req.User2Id, req.User2Id, req.User1Id,
+	).First(&match).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.CheckMatchResponse{IsMatch: false}, nil
+		}
+		return nil, err
+	}
+
+	return &pb.CheckMatchResponse{
+		IsMatch: true,
+		Match:   toPbMatch(match),
+	}, nil
+}
+
+func (m *MatchHandler) GetMatches(ctx context.Context, req *pb.GetMatchesRequest) (*pb.GetMatchesResponse, error) {
+	//use default limit if not provided
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	var matches []models.Match
+	err := m.db.Offset(int(req.Offset)).Limit(int(req.Limit)).
+		Where("user1_id = ? OR user2_id = ?", req.UserId, req.UserId).
+		Order("id").
+		Find(&matches).Error
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]*pb.Match, 0)
+	for _, match := range matches {
+		converted = append(converted, toPbMatch(match))
+	}
+
+	return &pb.GetMatchesResponse{
+		Matches: converted,
+	}, nil
+}
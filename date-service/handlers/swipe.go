@@ -2,16 +2,15 @@ package handlers
 
 import (
 	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
 	"date-service/entities"
 	"date-service/models"
-	pb "date-service/pb/generated"
 	"date-service/services"
-	"errors"
 	"fmt"
 	"time"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
@@ -33,35 +32,19 @@ func NewSwipeHandler(db *gorm.DB, profileService services.ProfileService, userSe
 }
 
 func (s *SwipeHandler) RecordSwipe(ctx context.Context, req *pb.RecordSwipeRequest) (*pb.RecordSwipeResponse, error) {
-	// validate token and get user
-	user, err := s.userService.ValidateAndGetUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
-
-	//validate requests
-	if req.SwiperUserId == 0 {
-		return nil, errors.New("swiper_user_id is required")
-	}
-
-	if req.SwipedProfileUserId == 0 {
-		return nil, errors.New("swiped_user_id is required")
-	}
-
-	if req.Action == "" {
-		return nil, errors.New("action is required")
-	}
+	// the caller is authenticated by the auth interceptor
+	user, _ := services.UserFromContext(ctx)
 
 	//check if swiper user id and swiped user id is the same
 	if req.SwiperUserId == req.SwipedProfileUserId {
-		return nil, errors.New("You cannot swipe yourself")
+		return nil, rpcerr.WithReason(codes.InvalidArgument, "SELF_SWIPE", "You cannot swipe yourself")
 	}
 
 	//check if user already swiped
 	var swipe models.Swipe
-	err = s.db.Where("swiper_user_id = ? AND swiped_profile_user_id = ?", req.SwiperUserId, req.SwipedProfileUserId).First(&swipe).Error
+	err := s.db.Where("swiper_user_id = ? AND swiped_profile_user_id = ?", req.SwiperUserId, req.SwipedProfileUserId).First(&swipe).Error
 	if err == nil {
-		return nil, errors.New("You have already swiped this profile")
+		return nil, rpcerr.WithReason(codes.AlreadyExists, "ALREADY_SWIPED", "You have already swiped this profile")
 	}
 
 	if !user.IsPremium {
@@ -76,7 +59,7 @@ func (s *SwipeHandler) RecordSwipe(ctx context.Context, req *pb.RecordSwipeReque
 
 		//revise the code below, if it is premium user, it can unlimited swipe
 		if len(swipes) >= 10 {
-			return nil, errors.New("You have reached the limit of swiping 10 times in 24 hours")
+			return nil, rpcerr.WithReason(codes.ResourceExhausted, "SWIPE_LIMIT_REACHED", "You have reached the limit of swiping 10 times in 24 hours")
 		}
 	}
 
@@ -92,14 +75,11 @@ func (s *SwipeHandler) RecordSwipe(ctx context.Context, req *pb.RecordSwipeReque
 		return nil, err
 	}
 
-	_, err = s.logService.AddLog(entities.ActivityLog{
+	s.logService.AddLog(entities.ActivityLog{
 		UserID:        uint(req.SwiperUserId),
 		ActionType:    "Swipe",
 		ActionDetails: fmt.Sprintf("User %d swipe user %d with action %s", swipe.SwiperUserID, swipe.SwipedProfileUserID, swipe.Action),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	// Check for a match if the swipe action is "like"
 	if req.Action == "like" {
@@ -116,14 +96,11 @@ func (s *SwipeHandler) RecordSwipe(ctx context.Context, req *pb.RecordSwipeReque
 				return nil, err
 			}
 
-			_, err = s.logService.AddLog(entities.ActivityLog{
+			s.logService.AddLog(entities.ActivityLog{
 				UserID:        swipe.SwiperUserID,
 				ActionType:    "Found Match",
 				ActionDetails: fmt.Sprintf("Found Match beteween user %d and user %d", req.SwiperUserId, req.SwipedProfileUserId),
 			})
-			if err != nil {
-				return nil, err
-			}
 
 			// Optionally, notify the users of the match (e.g., via a message queue or push notification)
 			fmt.Printf("Match found between user %d and user %d\n", req.SwiperUserId, req.SwipedProfileUserId)
@@ -146,23 +123,13 @@ func contains(slice []uint, value uint) bool {
 }
 
 func (s *SwipeHandler) GetSuggestions(ctx context.Context, req *pb.GetSuggestionsRequest) (*pb.GetSuggestionsResponse, error) {
-	// validate token and get user
-	_, err := s.userService.ValidateAndGetUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
-
 	//validate requests
-	if req.UserId == 0 {
-		return nil, errors.New("user_id is required")
-	}
-
 	if req.Limit == 0 {
 		req.Limit = 20
 	}
 
 	//get suggested profiles
-	profiles, err := s.profileService.GetProfiles(int(req.UserId), int(req.Limit))
+	profiles, err := s.profileService.GetProfiles(ctx, int(req.UserId), int(req.Limit))
 	if err != nil {
 		return nil, err
 	}
@@ -212,11 +179,6 @@ func (s *SwipeHandler) GetSuggestions(ctx context.Context, req *pb.GetSuggestion
 }
 
 func (s *SwipeHandler) GetSwipeHistory(ctx context.Context, req *pb.GetSwipeHistoryRequest) (*pb.GetSwipeHistoryResponse, error) {
-	//validate requests
-	if req.UserId == 0 {
-		return nil, errors.New("user_id is required")
-	}
-
 	//use default limit and offset if not provided
 	if req.Limit == 0 {
 		req.Limit = 20
@@ -228,7 +190,10 @@ func (s *SwipeHandler) GetSwipeHistory(ctx context.Context, req *pb.GetSwipeHist
 
 	//get all the swipes of the user
 	var swipes []models.Swipe
-	err := s.db.Offset(int(req.Offset)).Limit(int(req.Limit)).Where("swiper_user_id = ?", req.UserId).Find(&swipes).Error
+	err := s.db.Offset(int(req.Offset)).Limit(int(req.Limit)).
+		Where("swiper_user_id = ?", req.UserId).
+		Order("id").
+		Find(&swipes).Error
 	if err != nil {
 		return nil, err
 	}
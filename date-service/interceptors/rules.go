@@ -0,0 +1,45 @@
+package interceptors
+
+import (
+	"context"
+	"contracts/auth"
+	"contracts/pb"
+	"date-service/entities"
+)
+
+// SwipeRules is the policy table of the SwipeService and
+// MatchService RPCs. Users may only act on and read their own swipes and
+// matches; moderators and admins may read anyone's.
+func SwipeRules() map[string]auth.Rule[*entities.User] {
+	return map[string]auth.Rule[*entities.User]{
+		pb.SwipeService_RecordSwipe_FullMethodName: {
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return req.(*pb.RecordSwipeRequest).SwiperUserId == uint32(user.ID), nil
+			},
+		},
+		pb.SwipeService_GetSuggestions_FullMethodName: {
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return req.(*pb.GetSuggestionsRequest).UserId == uint32(user.ID), nil
+			},
+		},
+		pb.SwipeService_GetSwipeHistory_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return req.(*pb.GetSwipeHistoryRequest).UserId == uint32(user.ID), nil
+			},
+		},
+		pb.MatchService_CheckMatch_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				r := req.(*pb.CheckMatchRequest)
+				return r.User1Id == uint32(user.ID) || r.User2Id == uint32(user.ID), nil
+			},
+		},
+		pb.MatchService_GetMatches_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return req.(*pb.GetMatchesRequest).UserId == uint32(user.ID), nil
+			},
+		},
+	}
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"contracts/auth"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"contracts/validate"
 	"date-service/configs"
 	"date-service/handlers"
-	pb "date-service/pb/generated"
+	"date-service/interceptors"
 	"date-service/services"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"google.golang.org/grpc"
 )
@@ -21,10 +27,16 @@ func main() {
 	profileService := services.NewProfileService()
 	logService := services.NewLogService()
 	swipeHandler := handlers.NewSwipeHandler(db, profileService, userService, logService)
+	matchHandler := handlers.NewMatchHandler(db, userService)
 
-	grpcServer := grpc.NewServer()
+	authInterceptor := auth.NewInterceptor(userService, interceptors.SwipeRules())
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rpcerr.UnaryServerInterceptor, authInterceptor.Unary, validate.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(rpcerr.StreamServerInterceptor, authInterceptor.Stream, validate.StreamServerInterceptor),
+	)
 
 	pb.RegisterSwipeServiceServer(grpcServer, swipeHandler)
+	pb.RegisterMatchServiceServer(grpcServer, matchHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -38,7 +50,22 @@ func main() {
 	}
 
 	log.Printf("server listening at %s", listen.Addr().String())
+	go stopOnSignal(grpcServer)
 	if err := grpcServer.Serve(listen); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+
+	// deliver the activity logs still queued
+	logService.Close()
+}
+
+// stopOnSignal stops the server gracefully on SIGINT or SIGTERM, letting
+// the running calls finish.
+func stopOnSignal(grpcServer *grpc.Server) {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	log.Printf("shutting down")
+	grpcServer.GracefulStop()
 }
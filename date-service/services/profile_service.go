@@ -2,62 +2,81 @@ package services
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
+	"contracts/clients"
+	"contracts/pb"
 	"date-service/entities"
-	pb "date-service/pb/generated"
 	"log"
 	"os"
+	"sync"
+	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 type ProfileService interface {
-	GetProfiles(id int, limit int) ([]*entities.Profile, error)
-	CreateProfile(req entities.Profile) (*entities.Profile, error)
-	UpdateProfile(req entities.Profile) (*entities.Profile, error)
-	GetProfile(id int) (*entities.Profile, error)
+	GetProfiles(ctx context.Context, id int, limit int) ([]*entities.Profile, error)
+	CreateProfile(ctx context.Context, req entities.Profile) (*entities.Profile, error)
+	UpdateProfile(ctx context.Context, req entities.Profile) (*entities.Profile, error)
+	GetProfile(ctx context.Context, id int) (*entities.Profile, error)
 }
 
 func NewProfileClient() pb.ProfileServiceClient {
 	addr := os.Getenv("PROFILE_SERVICE_ADDR")
 
-	opts := []grpc.DialOption{}
-	systemRoots, err := x509.SystemCertPool()
+	client, err := clients.NewProfileClient(addr)
 	if err != nil {
-		log.Fatal(err)
-	}
-	cred := credentials.NewTLS(&tls.Config{
-		RootCAs: systemRoots,
-	})
-	opts = append(opts, grpc.WithTransportCredentials(cred))
-	conn, err := grpc.NewClient(addr, opts...)
-	// conn, err := grpc.Dial(addr, grpc.WithInsecure())
-	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("did not connect: %v", err)
 	}
 
-	client := pb.NewProfileServiceClient(conn)
-
 	return client
 }
 
+// suggestionsTTL is how long the last suggestions of a user are served
+// while profiles-service is unavailable.
+const suggestionsTTL = 10 * time.Minute
+
 func NewProfileService() ProfileService {
 	return &profileService{
 		profileClient: NewProfileClient(),
+		suggestions:   map[int]cachedSuggestions{},
 	}
 }
 
+type cachedSuggestions struct {
+	profiles []*entities.Profile
+	expires  time.Time
+}
+
 type profileService struct {
 	profileClient pb.ProfileServiceClient
+
+	mu          sync.Mutex
+	suggestions map[int]cachedSuggestions
+}
+
+// forwardAuth passes the caller's token on to profiles-service, which
+// authenticates every call.
+func forwardAuth(ctx context.Context) context.Context {
+	token, err := extractAuthToken(ctx)
+	if err != nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "auth_token", token)
 }
 
-func (p *profileService) GetProfiles(id int, limit int) ([]*entities.Profile, error) {
-	res, err := p.profileClient.GetProfilesSuggestion(context.TODO(), &pb.GetProfilesSuggestionRequest{
+// GetProfiles serves the last suggestions of the user while
+// profiles-service is unavailable, suggestions slightly out of date beat
+// none.
+func (p *profileService) GetProfiles(ctx context.Context, id int, limit int) ([]*entities.Profile, error) {
+	res, err := p.profileClient.GetProfilesSuggestion(forwardAuth(ctx), &pb.GetProfilesSuggestionRequest{
 		UserId: uint32(id),
 	})
 	if err != nil {
+		if cached, ok := p.cachedSuggestions(id); ok && clients.Unavailable(err) {
+			log.Printf("serving cached suggestions of user %d: %v", id, err)
+			return cached, nil
+		}
 		return nil, err
 	}
 
@@ -71,12 +90,37 @@ func (p *profileService) GetProfiles(id int, limit int) ([]*entities.Profile, er
 			Photos: profile.Photos,
 		})
 	}
+	p.cacheSuggestions(id, profiles)
 
 	return profiles, nil
 }
 
-func (p *profileService) CreateProfile(req entities.Profile) (*entities.Profile, error) {
-	res, err := p.profileClient.CreateProfile(context.TODO(), &pb.CreateProfileRequest{
+func (p *profileService) cachedSuggestions(id int) ([]*entities.Profile, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cached, ok := p.suggestions[id]
+	if !ok || !time.Now().Before(cached.expires) {
+		return nil, false
+	}
+	return cached.profiles, true
+}
+
+func (p *profileService) cacheSuggestions(id int, profiles []*entities.Profile) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for userID, cached := range p.suggestions {
+		if !now.Before(cached.expires) {
+			delete(p.suggestions, userID)
+		}
+	}
+	p.suggestions[id] = cachedSuggestions{profiles: profiles, expires: now.Add(suggestionsTTL)}
+}
+
+func (p *profileService) CreateProfile(ctx context.Context, req entities.Profile) (*entities.Profile, error) {
+	res, err := p.profileClient.CreateProfile(forwardAuth(ctx), &pb.CreateProfileRequest{
 		UserId: uint32(req.UserID),
 		Age:    int32(req.Age),
 		Bio:    req.Bio,
@@ -95,11 +139,11 @@ func (p *profileService) CreateProfile(req entities.Profile) (*entities.Profile,
 	}, nil
 }
 
-func (p *profileService) UpdateProfile(req entities.Profile) (*entities.Profile, error) {
-	res, err := p.profileClient.UpdateProfile(context.TODO(), &pb.UpdateProfileRequest{
-		UserId: uint32(req.UserID),
-		Age:    int32(req.Age),
-		Bio:    req.Bio,
+func (p *profileService) UpdateProfile(ctx context.Context, req entities.Profile) (*entities.Profile, error) {
+	res, err := p.profileClient.UpdateProfile(forwardAuth(ctx), &pb.UpdateProfileRequest{
+		Id:     uint32(req.ID),
+		Age:    proto.Int32(int32(req.Age)),
+		Bio:    proto.String(req.Bio),
 		Photos: req.Photos,
 	})
 	if err != nil {
@@ -115,9 +159,9 @@ func (p *profileService) UpdateProfile(req entities.Profile) (*entities.Profile,
 	}, nil
 }
 
-func (p *profileService) GetProfile(id int) (*entities.Profile, error) {
-	res, err := p.profileClient.GetProfile(context.TODO(), &pb.GetProfileRequest{
-		UserId: uint32(id),
+func (p *profileService) GetProfile(ctx context.Context, id int) (*entities.Profile, error) {
+	res, err := p.profileClient.GetProfile(forwardAuth(ctx), &pb.GetProfileRequest{
+		Id: uint32(id),
 	})
 	if err != nil {
 		return nil, err
@@ -2,44 +2,30 @@ package services
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
+	"contracts/auth"
+	"contracts/clients"
+	"contracts/pb"
 	"date-service/entities"
-	pb "date-service/pb/generated"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
 
 type UserService interface {
-	IsValidToken(token string) (*entities.User, error)
+	IsValidToken(ctx context.Context, token string) (*entities.User, error)
 	ValidateAndGetUser(c context.Context) (*entities.User, error)
 }
 
 func NewUserClient() pb.UserServiceClient {
 	addr := os.Getenv("USER_SERVICE_ADDR")
 
-	opts := []grpc.DialOption{}
-	systemRoots, err := x509.SystemCertPool()
+	client, err := clients.NewUserClient(addr)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("did not connect: %v", err)
 	}
-	cred := credentials.NewTLS(&tls.Config{
-		RootCAs: systemRoots,
-	})
-	opts = append(opts, grpc.WithTransportCredentials(cred))
-	conn, err := grpc.NewClient(addr, opts...)
-	// conn, err := grpc.Dial(addr, grpc.WithInsecure())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	client := pb.NewUserServiceClient(conn)
 
 	return client
 }
@@ -54,13 +40,13 @@ type userService struct {
 	userClient pb.UserServiceClient
 }
 
-func (u *userService) IsValidToken(token string) (*entities.User, error) {
+func (u *userService) IsValidToken(ctx context.Context, token string) (*entities.User, error) {
 	//validate requests
 	if token == "" {
 		return nil, errors.New("token is required")
 	}
 
-	res, err := u.userClient.IsValidToken(context.TODO(), &pb.IsValidTokenRequest{
+	res, err := u.userClient.IsValidToken(ctx, &pb.IsValidTokenRequest{
 		Token: token,
 	})
 	if err != nil {
@@ -73,6 +59,7 @@ func (u *userService) IsValidToken(token string) (*entities.User, error) {
 		Username:   res.User.Username,
 		IsPremium:  res.User.IsPremium,
 		IsVerified: res.User.IsVerified,
+		Role:       res.User.Role,
 	}, nil
 }
 
@@ -90,12 +77,21 @@ func extractAuthToken(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("auth_token not found in metadata")
 	}
 
-	log.Printf("auth_token found '%s'", tokens[0])
 	// Return the first token (in case there are multiple)
 	return tokens[0], nil
 }
 
+// UserFromContext returns the user stored by the auth interceptor, if any.
+func UserFromContext(ctx context.Context) (*entities.User, bool) {
+	return auth.UserFromContext[*entities.User](ctx)
+}
+
 func (u *userService) ValidateAndGetUser(c context.Context) (*entities.User, error) {
+	// already authenticated by the auth interceptor
+	if user, ok := UserFromContext(c); ok {
+		return user, nil
+	}
+
 	// extract token
 	token, err := extractAuthToken(c)
 	if err != nil {
@@ -103,7 +99,7 @@ func (u *userService) ValidateAndGetUser(c context.Context) (*entities.User, err
 	}
 
 	// call user Service
-	user, err := u.IsValidToken(token)
+	user, err := u.IsValidToken(c, token)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,27 @@
+package entities
+
+import "contracts/auth"
+
+// Roles a user can have, as issued by users-service. RoleService is never
+// issued, it is assigned to the other services calling with the shared
+// SERVICE_TOKEN.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+	RoleService   = "service"
+)
+
+type User struct {
+	ID         uint
+	Username   string
+	Email      string
+	IsPremium  bool
+	IsVerified bool
+	Role       string
+}
+
+// Identity tells the auth interceptor who the user is.
+func (u *User) Identity() auth.Identity {
+	return auth.Identity{ID: u.ID, Role: u.Role, IsPremium: u.IsPremium}
+}
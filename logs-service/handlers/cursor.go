@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cursor is the position of the last log of a page, page tokens encode it
+// opaquely, clients shouldn't rely on their content.
+type cursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+func encodeCursor(c cursor) string {
+	s := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "." + strconv.FormatUint(uint64(c.ID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func decodeCursor(token string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, err
+	}
+
+	createdAt, id, ok := strings.Cut(string(b), ".")
+	if !ok {
+		return cursor{}, errors.New("malformed cursor")
+	}
+	nanos, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return cursor{}, err
+	}
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return cursor{}, err
+	}
+	return cursor{CreatedAt: time.Unix(0, nanos), ID: uint(n)}, nil
+}
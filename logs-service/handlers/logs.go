@@ -2,14 +2,28 @@ package handlers
 
 import (
 	"context"
-	"errors"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"fmt"
+	"logs-service/entities"
 	"logs-service/models"
-	pb "logs-service/pb/generated"
+	"logs-service/services"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
+const (
+	// logs may be dated maxLogAge in the past at most, the services
+	// deliver the logs they spilled while logs-service was down within
+	// that time
+	maxLogAge = 7 * 24 * time.Hour
+	// and maxClockSkew in the future, the clocks of the services drift
+	maxClockSkew = time.Minute
+)
+
 type LogHandler struct {
 	pb.UnimplementedLogServiceServer
 	db *gorm.DB
@@ -22,76 +36,155 @@ func NewLogHandler(db *gorm.DB) *LogHandler {
 }
 
 func (l *LogHandler) AddLog(ctx context.Context, req *pb.AddLogRequest) (*pb.AddLogResponse, error) {
-	//validate requests
-	if req.UserId == 0 {
-		return nil, errors.New("user id is required")
+	if err := checkOccurredAt("occurred_at", req, time.Now()); err != nil {
+		return nil, err
 	}
 
-	if req.ActionType == "" {
-		return nil, errors.New("action type is required")
-	}
+	// create new log
+	log := newActivityLog(req)
 
-	if req.Details == "" {
-		return nil, errors.New("details is required")
+	err := l.db.Create(&log).Error
+	if err != nil {
+		return nil, err
 	}
 
-	// create new log
-	log := models.ActivityLog{
-		UserID:        uint(req.UserId),
-		ActionType:    req.ActionType,
-		ActionDetails: req.Details,
+	return &pb.AddLogResponse{
+		Status:   "Log Added Successfully",
+		LogEntry: toPbLogEntry(log),
+	}, nil
+}
+
+// AddLogs adds a batch of logs in a single transaction, the services send
+// their logs by batches in the background.
+func (l *LogHandler) AddLogs(ctx context.Context, req *pb.AddLogsRequest) (*pb.AddLogsResponse, error) {
+	now := time.Now()
+	logs := make([]models.ActivityLog, 0, len(req.Logs))
+	for i, entry := range req.Logs {
+		if err := checkOccurredAt(fmt.Sprintf("logs[%d].occurred_at", i), entry, now); err != nil {
+			return nil, err
+		}
+		logs = append(logs, newActivityLog(entry))
 	}
 
-	err := l.db.Create(&log).Error
+	err := l.db.Create(&logs).Error
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.AddLogResponse{
-		Status: "Log Added Successfully",
-		LogEntry: &pb.LogEntry{
-			Id:         uint32(log.ID),
-			UserId:     uint32(log.UserID),
-			ActionType: log.ActionType,
-			Details:    log.ActionDetails,
-			Timestamp:  time.Now().Format(time.RFC3339),
-		},
+	return &pb.AddLogsResponse{
+		Count: uint32(len(logs)),
 	}, nil
 }
 
+// checkOccurredAt rejects the logs dated too far from now, the callers
+// mustn't backdate nor postdate the activity of users.
+func checkOccurredAt(field string, req *pb.AddLogRequest, now time.Time) error {
+	if req.OccurredAt == nil {
+		return nil
+	}
+	occurredAt := req.OccurredAt.AsTime()
+	if occurredAt.Before(now.Add(-maxLogAge)) || occurredAt.After(now.Add(maxClockSkew)) {
+		return rpcerr.InvalidField(field, "occurred_at must be within the last 7 days, and a minute ahead at most")
+	}
+	return nil
+}
+
+// newActivityLog dates the log of req from when the action occurred, logs
+// may be delivered some time later.
+func newActivityLog(req *pb.AddLogRequest) models.ActivityLog {
+	log := models.ActivityLog{
+		UserID:        uint(req.UserId),
+		ActionType:    req.ActionType,
+		ActionDetails: req.Details,
+	}
+	if req.OccurredAt != nil {
+		log.CreatedAt = req.OccurredAt.AsTime()
+	}
+	return log
+}
+
+// GetLogs lists the logs of a user by time, then by id for logs of the
+// same time. Pages are fetched with the next_page_token of the previous
+// one, which keeps working while logs are added.
 func (l *LogHandler) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLogsResponse, error) {
-	// get all logs
+	//use default limit if not provided
+	if req.Limit == 0 {
+		req.Limit = 100
+	}
+
+	query := l.db.Where("user_id = ?", req.UserId)
+	if len(req.ActionTypes) > 0 {
+		query = query.Where("action_type IN ?", req.ActionTypes)
+	}
+	if req.Since != nil {
+		query = query.Where("created_at >= ?", req.Since.AsTime())
+	}
+	if req.Until != nil {
+		if req.Since != nil && !req.Until.AsTime().After(req.Since.AsTime()) {
+			return nil, rpcerr.InvalidField("until", "until must be after since")
+		}
+		query = query.Where("created_at < ?", req.Until.AsTime())
+	}
+
+	direction, after := "ASC", ">"
+	switch req.Order {
+	case pb.SortOrder_SORT_ORDER_UNSPECIFIED, pb.SortOrder_SORT_ORDER_ASC:
+	case pb.SortOrder_SORT_ORDER_DESC:
+		direction, after = "DESC", "<"
+	default:
+		return nil, rpcerr.InvalidField("order", "order must be SORT_ORDER_ASC or SORT_ORDER_DESC")
+	}
+
+	if req.PageToken != "" {
+		cursor, err := decodeCursor(req.PageToken)
+		if err != nil {
+			return nil, rpcerr.InvalidField("page_token", "invalid page token")
+		}
+		query = query.Where(fmt.Sprintf("(created_at, id) %s (?, ?)", after), cursor.CreatedAt, cursor.ID)
+	} else {
+		query = query.Offset(int(req.Offset))
+	}
+
+	// get the user's logs, plus one telling whether there is a next page
 	var logs []models.ActivityLog
-	err := l.db.Find(&logs).Error
+	err := query.
+		Order(fmt.Sprintf("created_at %s, id %s", direction, direction)).
+		Limit(int(req.Limit) + 1).
+		Find(&logs).Error
 	if err != nil {
 		return nil, err
 	}
 
+	res := &pb.GetLogsResponse{}
+	if len(logs) > int(req.Limit) {
+		logs = logs[:req.Limit]
+		last := logs[len(logs)-1]
+		res.NextPageToken = encodeCursor(cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
 	// convert logs to pb
-	var pbLogs []*pb.LogEntry
 	for _, log := range logs {
-		pbLogs = append(pbLogs, &pb.LogEntry{
-			Id:         uint32(log.ID),
-			UserId:     uint32(log.UserID),
-			ActionType: log.ActionType,
-			Details:    log.ActionDetails,
-			Timestamp:  time.Now().Format(time.RFC3339),
-		})
+		res.Logs = append(res.Logs, toPbLogEntry(log))
 	}
 
-	return &pb.GetLogsResponse{
-		Logs: pbLogs,
-	}, nil
+	return res, nil
 }
 
 func (l *LogHandler) StreamLogs(req *pb.StreamLogsRequest, stream pb.LogService_StreamLogsServer) error {
+	// streams have no owner check in the auth interceptor, users may only
+	// follow their own logs
+	user, _ := services.UserFromContext(stream.Context())
+	if user.Role != entities.RoleModerator && user.Role != entities.RoleAdmin && req.UserId != uint32(user.ID) {
+		return status.Errorf(codes.PermissionDenied, "user %d does not own the requested resource", user.ID)
+	}
+
 	// Keep track of the last processed log ID
 	var lastLogID uint
 
 	for {
 		// Fetch new logs since the last processed ID
 		var logs []models.ActivityLog
-		err := l.db.Where("user_id = ? AND id > ?", req.UserId, lastLogID).Find(&logs).Error
+		err := l.db.Where("user_id = ? AND id > ?", req.UserId, lastLogID).Order("id").Find(&logs).Error
 		if err != nil {
 			return err
 		}
@@ -99,13 +192,7 @@ func (l *LogHandler) StreamLogs(req *pb.StreamLogsRequest, stream pb.LogService_
 		// Stream new logs to the client
 		for _, log := range logs {
 			err := stream.Send(&pb.StreamLogsResponse{
-				LogEntry: &pb.LogEntry{
-					Id:         uint32(log.ID),
-					UserId:     uint32(log.UserID),
-					ActionType: log.ActionType,
-					Details:    log.ActionDetails,
-					Timestamp:  time.Now().Format(time.RFC3339),
-				},
+				LogEntry: toPbLogEntry(log),
 			})
 			if err != nil {
 				return err
@@ -119,3 +206,13 @@ func (l *LogHandler) StreamLogs(req *pb.StreamLogsRequest, stream pb.LogService_
 		time.Sleep(1 * time.Second)
 	}
 }
+
+func toPbLogEntry(log models.ActivityLog) *pb.LogEntry {
+	return &pb.LogEntry{
+		Id:         uint32(log.ID),
+		UserId:     uint32(log.UserID),
+		ActionType: log.ActionType,
+		Details:    log.ActionDetails,
+		Timestamp:  log.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
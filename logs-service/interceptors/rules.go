@@ -0,0 +1,24 @@
+package interceptors
+
+import (
+	"context"
+	"contracts/auth"
+	"contracts/pb"
+	"logs-service/entities"
+)
+
+// LogRules is the policy table of the LogService RPCs. Users may only read
+// their own logs, moderators and admins may read anyone's.
+func LogRules() map[string]auth.Rule[*entities.User] {
+	return map[string]auth.Rule[*entities.User]{
+		// only the other services add logs, with the shared SERVICE_TOKEN
+		pb.LogService_AddLog_FullMethodName:  {Roles: []string{entities.RoleService}},
+		pb.LogService_AddLogs_FullMethodName: {Roles: []string{entities.RoleService}},
+		pb.LogService_GetLogs_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return req.(*pb.GetLogsRequest).UserId == uint32(user.ID), nil
+			},
+		},
+	}
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"contracts/auth"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"contracts/validate"
 	"fmt"
 	"log"
 	"logs-service/configs"
 	"logs-service/handlers"
-	pb "logs-service/pb/generated"
+	"logs-service/interceptors"
+	"logs-service/services"
 	"net"
 	"os"
 
@@ -17,8 +22,13 @@ func main() {
 
 	//instantiate services
 	logHandler := handlers.NewLogHandler(db)
+	userService := services.NewUserService()
 
-	grpcServer := grpc.NewServer()
+	authInterceptor := auth.NewInterceptor(userService, interceptors.LogRules())
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rpcerr.UnaryServerInterceptor, authInterceptor.Unary, validate.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(rpcerr.StreamServerInterceptor, authInterceptor.Stream, validate.StreamServerInterceptor),
+	)
 
 	pb.RegisterLogServiceServer(grpcServer, logHandler)
 
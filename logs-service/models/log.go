@@ -1,10 +1,19 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// ActivityLog declares the gorm.Model fields itself to index CreatedAt.
+// Logs are listed per user by time, optionally filtered by action type.
 type ActivityLog struct {
-	gorm.Model
-	UserID        uint   `gorm:"not null"` // Foreign key to Users table
-	ActionType    string `gorm:"type:varchar(20);not null"`
-	ActionDetails string `gorm:"type:text"`
+	ID            uint      `gorm:"primarykey;index:idx_activity_logs_user_time,priority:3"`
+	CreatedAt     time.Time `gorm:"index:idx_activity_logs_user_time,priority:2;index:idx_activity_logs_user_action,priority:3"`
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	UserID        uint           `gorm:"not null;index:idx_activity_logs_user_time,priority:1;index:idx_activity_logs_user_action,priority:1"` // Foreign key to Users table
+	ActionType    string         `gorm:"type:varchar(100);not null;index:idx_activity_logs_user_action,priority:2"`
+	ActionDetails string         `gorm:"type:text"`
 }
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"contracts/auth"
+	"contracts/clients"
+	"contracts/pb"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"logs-service/entities"
+	"os"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type UserService interface {
+	IsValidToken(ctx context.Context, token string) (*entities.User, error)
+	ValidateAndGetUser(c context.Context) (*entities.User, error)
+}
+
+func NewUserClient() pb.UserServiceClient {
+	addr := os.Getenv("USER_SERVICE_ADDR")
+
+	client, err := clients.NewUserClient(addr)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+
+	return client
+}
+
+func NewUserService() UserService {
+	return &userService{
+		userClient: NewUserClient(),
+	}
+}
+
+type userService struct {
+	userClient pb.UserServiceClient
+}
+
+func (u *userService) IsValidToken(ctx context.Context, token string) (*entities.User, error) {
+	//validate requests
+	if token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	res, err := u.userClient.IsValidToken(ctx, &pb.IsValidTokenRequest{
+		Token: token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.User{
+		ID:         uint(res.User.Id),
+		Email:      res.User.Email,
+		Username:   res.User.Username,
+		IsPremium:  res.User.IsPremium,
+		IsVerified: res.User.IsVerified,
+		Role:       res.User.Role,
+	}, nil
+}
+
+func extractAuthToken(ctx context.Context) (string, error) {
+	// Retrieve metadata from the context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		log.Print("no metadata found in context")
+		return "", fmt.Errorf("no metadata found in context")
+	}
+	// Check if 'auth_token' exists in the metadata
+	tokens := md["auth_token"]
+	if len(tokens) == 0 {
+		log.Print("auth_token not found in metadata")
+		return "", fmt.Errorf("auth_token not found in metadata")
+	}
+
+	// Return the first token (in case there are multiple)
+	return tokens[0], nil
+}
+
+// isServiceCall reports whether the caller is another backend service
+// authenticated with the shared SERVICE_TOKEN.
+func isServiceCall(ctx context.Context) bool {
+	serviceToken := os.Getenv("SERVICE_TOKEN")
+	if serviceToken == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	tokens := md["service_token"]
+	return len(tokens) > 0 && subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(serviceToken)) == 1
+}
+
+// UserFromContext returns the user stored by the auth interceptor, if any.
+func UserFromContext(ctx context.Context) (*entities.User, bool) {
+	return auth.UserFromContext[*entities.User](ctx)
+}
+
+func (u *userService) ValidateAndGetUser(c context.Context) (*entities.User, error) {
+	// already authenticated by the auth interceptor
+	if user, ok := UserFromContext(c); ok {
+		return user, nil
+	}
+
+	if isServiceCall(c) {
+		return &entities.User{Username: "service", Role: entities.RoleService}, nil
+	}
+
+	// extract token
+	token, err := extractAuthToken(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// call user Service
+	user, err := u.IsValidToken(c, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,49 @@
+package interceptors
+
+import (
+	"context"
+	"contracts/auth"
+	"contracts/pb"
+	"errors"
+	"payment-service/models"
+	"payment-service/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// PaymentRules is the policy table of the SubPayment RPCs.
+// CompletePayment is called by the payment gateway and verified with the
+// webhook token instead.
+func PaymentRules(db *gorm.DB) map[string]auth.Rule[*services.User] {
+	return map[string]auth.Rule[*services.User]{
+		pb.SubPayment_CompletePayment_FullMethodName: {Access: auth.Public},
+		// users only subscribe themselves, the handler subscribes the caller
+		pb.SubPayment_CreateUserSubcription_FullMethodName: {
+			Owner: func(ctx context.Context, req any, user *services.User) (bool, error) {
+				return req.(*pb.CreateUserSubcriptionReq).UserId == int64(user.ID), nil
+			},
+		},
+		pb.SubPayment_GetUserSubcriptions_FullMethodName: {
+			Roles: []string{services.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *services.User) (bool, error) {
+				return req.(*pb.GetUserSubcriptionsReq).UserId == int64(user.ID), nil
+			},
+		},
+		pb.SubPayment_GetPaymentByID_FullMethodName: {
+			Roles: []string{services.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *services.User) (bool, error) {
+				var payment models.Payment
+				err := db.Where("id=?", req.(*pb.GetPaymentByIDReq).PaymentId).First(&payment).Error
+				if err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return false, status.Errorf(codes.NotFound, "payment not found")
+					}
+					return false, err
+				}
+				return payment.UserID == user.ID, nil
+			},
+		},
+	}
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"contracts/auth"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"contracts/validate"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
 	"payment-service/configs"
-	pb "payment-service/pb/generated"
+	"payment-service/interceptors"
 	"payment-service/server"
 	"payment-service/services"
+	"syscall"
 
 	"google.golang.org/grpc"
 )
@@ -29,11 +35,10 @@ func main() {
 		logService,
 	)
 
+	authInterceptor := auth.NewInterceptor(userService, interceptors.PaymentRules(db))
 	opts := []grpc.ServerOption{
-		// The following grpc.ServerOption adds an interceptor for all unary
-		// RPCs. To configure an interceptor for streaming RPCs, see:
-		// https://godoc.org/google.golang.org/grpc#StreamInterceptor
-		// grpc.UnaryInterceptor(jwtIntercept.EnsureValidToken),
+		grpc.ChainUnaryInterceptor(rpcerr.UnaryServerInterceptor, authInterceptor.Unary, validate.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(rpcerr.StreamServerInterceptor, authInterceptor.Stream, validate.StreamServerInterceptor),
 	}
 	port := os.Getenv("PORT")
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
@@ -43,5 +48,22 @@ func main() {
 	grpcServer := grpc.NewServer(opts...)
 	pb.RegisterSubPaymentServer(grpcServer, paymentServer)
 	log.Printf("starting gRPC server on %s", port)
-	log.Fatal(grpcServer.Serve(lis))
+	go stopOnSignal(grpcServer)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+
+	// deliver the activity logs still queued
+	logService.Close()
+}
+
+// stopOnSignal stops the server gracefully on SIGINT or SIGTERM, letting
+// the running calls finish.
+func stopOnSignal(grpcServer *grpc.Server) {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	log.Printf("shutting down")
+	grpcServer.GracefulStop()
 }
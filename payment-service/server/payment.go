@@ -2,17 +2,16 @@ package server
 
 import (
 	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"payment-service/entities"
 	"payment-service/models"
-	pb "payment-service/pb/generated"
 	"payment-service/services"
 	"strconv"
-	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -48,18 +47,13 @@ func (ps *PaymentServer) CompletePayment(c context.Context, req *pb.CompletePaym
 	verifToken := req.CallbackToken
 	if verifToken == "" || verifToken != os.Getenv("XENDIT_WEBHOOK_TOKEN") {
 		log.Printf("received xendit webhook token: %s", verifToken)
-		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook token")
-	}
-
-	// validate req
-	if req.PaymentMethod == "" || req.PaidAmount <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "payment method and paid amount cannot be empty")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid webhook token")
 	}
 
 	// get corresponding payment
 	paymentId, err := strconv.Atoi(req.ExternalId)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid payment id: %s", req.ExternalId)
+		return nil, rpcerr.InvalidField("external_id", fmt.Sprintf("invalid payment id: %s", req.ExternalId))
 	}
 	var payment models.Payment
 	err = ps.db.Where("id=?", paymentId).Select("*").First(&payment).Error
@@ -71,7 +65,7 @@ func (ps *PaymentServer) CompletePayment(c context.Context, req *pb.CompletePaym
 
 	// check payment not updated yet
 	if payment.Status != "pending" {
-		return nil, status.Errorf(http.StatusBadRequest, "payment already updated")
+		return nil, rpcerr.WithReason(codes.FailedPrecondition, "PAYMENT_ALREADY_UPDATED", "payment already updated")
 	}
 
 	var transDate time.Time
@@ -89,38 +83,32 @@ func (ps *PaymentServer) CompletePayment(c context.Context, req *pb.CompletePaym
 	}
 	err = ps.db.Save(&payment).Error
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "internal server error: %s", err.Error())
+		return nil, status.Errorf(codes.Internal, "internal server error: %s", err.Error())
 	}
 
-	_, err = ps.logService.AddLog(entities.ActivityLog{
+	ps.logService.AddLog(entities.ActivityLog{
 		UserID:        uint(payment.UserID),
 		ActionType:    "Paid Invoice",
 		ActionDetails: fmt.Sprintf("User %d Paid Invoice with ID %d", payment.UserID, payment.ID),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	// get user
-	user, err := ps.userService.GetUserByID(payment.UserID)
+	user, err := ps.userService.GetUserByID(c, payment.UserID)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to update user tier %s", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to update user tier %s", err.Error())
 	}
 	// update user tier
 	user.IsPremium = true
-	_, err = ps.userService.UpdateUser(user)
+	_, err = ps.userService.UpdateUser(c, user)
 	if err != nil {
-		return nil, status.Errorf(http.StatusInternalServerError, "failed to update user tier %s", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to update user tier %s", err.Error())
 	}
 
-	_, err = ps.logService.AddLog(entities.ActivityLog{
+	ps.logService.AddLog(entities.ActivityLog{
 		UserID:        uint(payment.UserID),
 		ActionType:    "Update User Tier",
 		ActionDetails: fmt.Sprintf("Succes Updating User %d Tier", payment.UserID),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.CompletePaymentResp{
 		Payment: &pb.Payment{
@@ -136,25 +124,6 @@ func (ps *PaymentServer) CompletePayment(c context.Context, req *pb.CompletePaym
 	}, nil
 }
 
-func (ps *PaymentServer) validateUserSubscriptionData(req *pb.CreateUserSubcriptionReq) error {
-	// Check if UserID is provided and valid
-	if req.UserId <= 0 {
-		return errors.New("user_id must be a positive integer")
-	}
-
-	// Check if Tier is provided and valid (non-empty and meaningful)
-	if strings.TrimSpace(req.Tier) == "" && strings.TrimSpace(req.Tier) == "business" {
-		return errors.New("tier cannot be empty")
-	}
-
-	// Check if Duration is valid (positive integer)
-	if req.Duration <= 0 {
-		return errors.New("duration must be a positive integer")
-	}
-
-	return nil
-}
-
 func (ps *PaymentServer) rollbackUserSub(userSub *models.UserSubscription) {
 	payment := userSub.Payment
 	err := ps.db.Delete(&userSub).Error
@@ -169,21 +138,12 @@ func (ps *PaymentServer) rollbackUserSub(userSub *models.UserSubscription) {
 
 // CreateUserSubcription implements pb.SubPaymentServer.
 func (ps *PaymentServer) CreateUserSubcription(c context.Context, req *pb.CreateUserSubcriptionReq) (*pb.CreateUserSubcriptionResp, error) {
-	// validate token and get user
-	err := ps.validateUserSubscriptionData(req)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %s", err.Error())
-	}
-
-	// validate token and get user
-	user, err := ps.userService.ValidateAndGetUser(c)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
+	// the caller is authenticated by the auth interceptor
+	user, _ := services.UserFromContext(c)
 
 	// get subscribtion
 	var sub models.Subscription
-	err = ps.db.Where("tier=?", req.Tier).First(&sub).Error
+	err := ps.db.Where("tier=?", req.Tier).First(&sub).Error
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "subscription tier '%s' does not exist", req.GetTier())
 	}
@@ -231,14 +191,11 @@ func (ps *PaymentServer) CreateUserSubcription(c context.Context, req *pb.Create
 		return nil, status.Errorf(codes.Internal, "failed to update payment invoice: %s", err.Error())
 	}
 
-	_, err = ps.logService.AddLog(entities.ActivityLog{
+	ps.logService.AddLog(entities.ActivityLog{
 		UserID:        uint(newPayment.UserID),
 		ActionType:    "Creating Invoice",
 		ActionDetails: fmt.Sprintf("Succes Creating Invoice for User %d with Payment ID %d", newPayment.UserID, newPayment.ID),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.CreateUserSubcriptionResp{
 		Id:     int64(newUserSub.ID),
@@ -262,15 +219,52 @@ func (ps *PaymentServer) CreateUserSubcription(c context.Context, req *pb.Create
 }
 
 // GetUserSubcriptions implements pb.SubPaymentServer.
-func (ps *PaymentServer) GetUserSubcriptions(context.Context, *pb.GetUserSubcriptionsReq) (*pb.GetUserSubcriptionsResp, error) {
-	panic("unimplemented")
-}
+func (ps *PaymentServer) GetUserSubcriptions(c context.Context, req *pb.GetUserSubcriptionsReq) (*pb.GetUserSubcriptionsResp, error) {
+	var userSubs []models.UserSubscription
+	err := ps.db.Preload("Subscription").Preload("Payment").
+		Where("user_id=?", req.UserId).
+		Order("id").
+		Find(&userSubs).Error
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error: %s", err.Error())
+	}
 
-func (ps *PaymentServer) GetPaymentByID(c context.Context, req *pb.GetPaymentByIDReq) (*pb.GetPaymentByIDResp, error) {
-	if req.PaymentId <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "payment id must be a positive integer")
+	resp := &pb.GetUserSubcriptionsResp{
+		UserSubscriptions: []*pb.UserSubscription{},
+	}
+	for _, userSub := range userSubs {
+		pbUserSub := &pb.UserSubscription{
+			Id:     int64(userSub.ID),
+			UserId: int64(userSub.UserID),
+			Subscription: &pb.Subscription{
+				Id:            int64(userSub.Subscription.ID),
+				Tier:          userSub.Subscription.Tier,
+				PricePerMonth: float32(userSub.Subscription.PricePerMonth),
+			},
+			Duration: int64(userSub.Duration),
+			Payment: &pb.Payment{
+				Id:             int64(userSub.Payment.ID),
+				UserId:         int64(userSub.Payment.UserID),
+				PaymentGateway: userSub.Payment.PaymentGateway,
+				Amount:         float32(userSub.Payment.Amount),
+				Currency:       userSub.Payment.Currency,
+				Status:         userSub.Payment.Status,
+				Url:            userSub.Payment.Url,
+			},
+		}
+		if userSub.EndDate != nil {
+			pbUserSub.EndDate = timestamppb.New(*userSub.EndDate)
+		}
+		if userSub.Payment.TransactionDate != nil {
+			pbUserSub.Payment.TransactionDate = timestamppb.New(*userSub.Payment.TransactionDate)
+		}
+		resp.UserSubscriptions = append(resp.UserSubscriptions, pbUserSub)
 	}
 
+	return resp, nil
+}
+
+func (ps *PaymentServer) GetPaymentByID(c context.Context, req *pb.GetPaymentByIDReq) (*pb.GetPaymentByIDResp, error) {
 	var payment models.Payment
 	err := ps.db.Where("id=?", req.PaymentId).First(&payment).Error
 	if err != nil {
@@ -2,55 +2,53 @@ package services
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
+	"contracts/auth"
+	"contracts/clients"
+	"contracts/pb"
 	"fmt"
 	"log"
 	"os"
-	pb "payment-service/pb/generated"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
 
+// Roles a user can have, as issued by users-service.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
 type User struct {
 	ID         int
 	Username   string
 	Email      string
 	IsPremium  bool
 	IsVerified bool
+	Role       string
+}
+
+// Identity tells the auth interceptor who the user is.
+func (u *User) Identity() auth.Identity {
+	return auth.Identity{ID: uint(u.ID), Role: u.Role, IsPremium: u.IsPremium}
 }
 
 func NewUserClient() pb.UserServiceClient {
 	addr := os.Getenv("USER_SERVICE_ADDR")
 	log.Printf("user service url: %s", addr)
-	// Set up a connection to the server.
-	opts := []grpc.DialOption{}
-	systemRoots, err := x509.SystemCertPool()
-	if err != nil {
-		log.Fatalf("filed to get certs: %v", err)
-	}
-	cred := credentials.NewTLS(&tls.Config{
-		RootCAs: systemRoots,
-	})
-	opts = append(opts, grpc.WithTransportCredentials(cred))
-	conn, err := grpc.NewClient(addr, opts...)
-	// conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	client, err := clients.NewUserClient(addr)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 
-	client := pb.NewUserServiceClient(conn)
-
 	return client
 }
 
 type UserService interface {
-	IsValidToken(token string) (*User, error)
+	IsValidToken(ctx context.Context, token string) (*User, error)
 	ValidateAndGetUser(c context.Context) (*User, error)
-	GetUserByID(id int) (*User, error)
-	UpdateUser(user *User) (*User, error)
+	GetUserByID(ctx context.Context, id int) (*User, error)
+	UpdateUser(ctx context.Context, user *User) (*User, error)
 }
 
 func NewUserService() UserService {
@@ -63,8 +61,8 @@ type userService struct {
 	userClient pb.UserServiceClient
 }
 
-func (u *userService) IsValidToken(token string) (*User, error) {
-	res, err := u.userClient.IsValidToken(context.TODO(), &pb.IsValidTokenRequest{
+func (u *userService) IsValidToken(ctx context.Context, token string) (*User, error) {
+	res, err := u.userClient.IsValidToken(ctx, &pb.IsValidTokenRequest{
 		Token: token,
 	})
 	if err != nil {
@@ -77,6 +75,7 @@ func (u *userService) IsValidToken(token string) (*User, error) {
 		Email:      res.User.Email,
 		IsPremium:  res.User.IsPremium,
 		IsVerified: res.User.IsVerified,
+		Role:       res.User.Role,
 	}, nil
 }
 
@@ -95,12 +94,29 @@ func extractAuthToken(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("auth_token not found in metadata")
 	}
 
-	log.Printf("auth_token found '%s'", tokens[0])
 	// Return the first token (in case there are multiple)
 	return tokens[0], nil
 }
 
+// UserFromContext returns the user stored by the auth interceptor, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	return auth.UserFromContext[*User](ctx)
+}
+
+// serviceContext authenticates calls to users-service as this service
+// rather than as an end user, using the shared SERVICE_TOKEN. The calls
+// keep the deadline of ctx.
+func serviceContext(ctx context.Context) context.Context {
+	md := metadata.Pairs("service_token", os.Getenv("SERVICE_TOKEN"))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
 func (u *userService) ValidateAndGetUser(c context.Context) (*User, error) {
+	// already authenticated by the auth interceptor
+	if user, ok := UserFromContext(c); ok {
+		return user, nil
+	}
+
 	// extract token
 	token, err := extractAuthToken(c)
 	if err != nil {
@@ -108,7 +124,7 @@ func (u *userService) ValidateAndGetUser(c context.Context) (*User, error) {
 	}
 
 	// call user Service
-	user, err := u.IsValidToken(token)
+	user, err := u.IsValidToken(c, token)
 	if err != nil {
 		return nil, err
 	}
@@ -119,12 +135,13 @@ func (u *userService) ValidateAndGetUser(c context.Context) (*User, error) {
 		Email:      user.Email,
 		IsPremium:  user.IsPremium,
 		IsVerified: user.IsVerified,
+		Role:       user.Role,
 	}, nil
 }
 
-func (u *userService) GetUserByID(id int) (*User, error) {
+func (u *userService) GetUserByID(ctx context.Context, id int) (*User, error) {
 
-	res, err := u.userClient.GetUser(context.TODO(), &pb.GetUserRequest{
+	res, err := u.userClient.GetUser(serviceContext(ctx), &pb.GetUserRequest{
 		Id: uint32(id),
 	})
 	if err != nil {
@@ -137,11 +154,12 @@ func (u *userService) GetUserByID(id int) (*User, error) {
 		Email:      res.User.Email,
 		IsPremium:  res.User.IsPremium,
 		IsVerified: res.User.IsVerified,
+		Role:       res.User.Role,
 	}, nil
 }
 
-func (u *userService) UpdateUser(user *User) (*User, error) {
-	res, err := u.userClient.UpdateUser(context.TODO(), &pb.UpdateUserRequest{
+func (u *userService) UpdateUser(ctx context.Context, user *User) (*User, error) {
+	res, err := u.userClient.UpdateUser(serviceContext(ctx), &pb.UpdateUserRequest{
 		Username:   user.Username,
 		Email:      user.Email,
 		IsPremium:  user.IsPremium,
@@ -158,5 +176,6 @@ func (u *userService) UpdateUser(user *User) (*User, error) {
 		Email:      res.User.Email,
 		IsPremium:  res.User.IsPremium,
 		IsVerified: res.User.IsVerified,
+		Role:       res.User.Role,
 	}, nil
 }
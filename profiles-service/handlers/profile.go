@@ -2,15 +2,16 @@ package handlers
 
 import (
 	"context"
+	"contracts/pb"
 	"errors"
 	"fmt"
 	"profiles-service/entities"
 	"profiles-service/models"
-	pb "profiles-service/pb/generated"
 	"profiles-service/services"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
 
@@ -29,12 +30,18 @@ func NewProfileHandler(db *gorm.DB, userService services.UserService, logService
 	}
 }
 
-func (p *ProfileHandler) GetProfilesSuggestion(ctx context.Context, req *pb.GetProfilesSuggestionRequest) (*pb.GetProfilesSuggestionResponse, error) {
-	//validate the field
-	if req.UserId == 0 {
-		return nil, errors.New("user_id is required")
+func toPbProfile(profile models.Profile) *pb.Profile {
+	return &pb.Profile{
+		Id:        uint32(profile.ID),
+		UserId:    uint32(profile.UserID),
+		Age:       int32(profile.Age),
+		Bio:       profile.Bio,
+		Photos:    profile.Photos,
+		UpdatedAt: timestamppb.New(profile.UpdatedAt),
 	}
+}
 
+func (p *ProfileHandler) GetProfilesSuggestion(ctx context.Context, req *pb.GetProfilesSuggestionRequest) (*pb.GetProfilesSuggestionResponse, error) {
 	// get all profiles except the user
 
 	profiles := []models.Profile{}
@@ -45,13 +52,7 @@ func (p *ProfileHandler) GetProfilesSuggestion(ctx context.Context, req *pb.GetP
 
 	profilesResponse := []*pb.Profile{}
 	for _, profile := range profiles {
-		profilesResponse = append(profilesResponse, &pb.Profile{
-			Id:     uint32(profile.ID),
-			UserId: uint32(profile.UserID),
-			Age:    int32(profile.Age),
-			Bio:    profile.Bio,
-			Photos: profile.Photos,
-		})
+		profilesResponse = append(profilesResponse, toPbProfile(profile))
 	}
 
 	return &pb.GetProfilesSuggestionResponse{
@@ -60,61 +61,38 @@ func (p *ProfileHandler) GetProfilesSuggestion(ctx context.Context, req *pb.GetP
 }
 
 func (p *ProfileHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
-	// validate token and get user
-	user, err := p.userService.ValidateAndGetUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
-
-	//validate the field
-	if req.Id == 0 {
-		return nil, errors.New("id is required")
-	}
-
 	profile := models.Profile{}
-	err = p.db.Where("id = ?", req.Id).First(&profile).Error
+	err := p.db.Where("id = ?", req.Id).First(&profile).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("profile not found")
+			return nil, status.Errorf(codes.NotFound, "profile not found")
 		}
 		return nil, err
 	}
 
-	_, err = p.logService.AddLog(entities.ActivityLog{
-		UserID:        user.ID,
-		ActionType:    "Get Profile",
-		ActionDetails: fmt.Sprintf("User %s Getting Profile with Profile ID %d", user.Username, profile.ID),
-	})
+	return &pb.GetProfileResponse{
+		Profile: toPbProfile(profile),
+	}, nil
+}
+
+func (p *ProfileHandler) GetProfileByUserID(ctx context.Context, req *pb.GetProfileByUserIDRequest) (*pb.GetProfileResponse, error) {
+	profile := models.Profile{}
+	err := p.db.Where("user_id = ?", req.UserId).First(&profile).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
 		return nil, err
 	}
 
 	return &pb.GetProfileResponse{
-		Profile: &pb.Profile{
-			Id:     uint32(profile.ID),
-			UserId: uint32(profile.UserID),
-			Age:    int32(profile.Age),
-			Bio:    profile.Bio,
-			Photos: profile.Photos,
-		},
+		Profile: toPbProfile(profile),
 	}, nil
 }
 
 func (p *ProfileHandler) CreateProfile(ctx context.Context, req *pb.CreateProfileRequest) (*pb.CreateProfileResponse, error) {
-	// validate token and get user
-	user, err := p.userService.ValidateAndGetUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
-
-	//validate the field
-	if req.Age == 0 {
-		return nil, errors.New("age is required")
-	}
-
-	if req.Bio == "" {
-		return nil, errors.New("bio is required")
-	}
+	// the caller is authenticated by the auth interceptor
+	user, _ := services.UserFromContext(ctx)
 
 	// create new profile
 	profile := models.Profile{
@@ -124,118 +102,80 @@ func (p *ProfileHandler) CreateProfile(ctx context.Context, req *pb.CreateProfil
 		Photos: req.Photos,
 	}
 
-	err = p.db.Create(&profile).Error
+	err := p.db.Create(&profile).Error
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = p.logService.AddLog(entities.ActivityLog{
+	p.logService.AddLog(entities.ActivityLog{
 		UserID:        user.ID,
 		ActionType:    "Create Profile",
 		ActionDetails: fmt.Sprintf("User %s Creating Profile with ID %d", user.Username, profile.ID),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.CreateProfileResponse{
-		Status: "Successfully created profile",
-		Profile: &pb.Profile{
-			Id:     uint32(profile.ID),
-			UserId: uint32(profile.UserID),
-			Age:    int32(profile.Age),
-			Bio:    profile.Bio,
-			Photos: profile.Photos,
-		},
+		Status:  "Successfully created profile",
+		Profile: toPbProfile(profile),
 	}, nil
 }
 
 func (p *ProfileHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
-	// validate token and get user
-	user, err := p.userService.ValidateAndGetUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
-
-	//validate the field
-	if req.Age == 0 {
-		return nil, errors.New("age is required")
-	}
-
-	if req.Bio == "" {
-		return nil, errors.New("bio is required")
-	}
+	// the caller is authenticated by the auth interceptor
+	user, _ := services.UserFromContext(ctx)
 
 	//check if the profile exists
 	profile := models.Profile{}
-	err = p.db.Where("id = ?", req.Id).First(&profile).Error
+	err := p.db.Where("id = ?", req.Id).First(&profile).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("profile not found")
+			return nil, status.Errorf(codes.NotFound, "profile not found")
 		}
 		return nil, err
 	}
 
-	// create updated new profile
-
-	toUpdate := models.Profile{
-		UserID: uint(user.ID),
-		Age:    int(req.Age),
-		Bio:    req.Bio,
-		Photos: req.Photos,
+	// only the fields set in the request change, the owner never changes so
+	// moderators and admins can edit other users' profiles
+	if req.Age != nil {
+		profile.Age = int(*req.Age)
+	}
+	if req.Bio != nil {
+		profile.Bio = *req.Bio
+	}
+	if len(req.Photos) > 0 {
+		profile.Photos = req.Photos
 	}
 
-	err = p.db.Model(&models.Profile{}).Where("id = ?", req.Id).Updates(&toUpdate).Error
+	err = p.db.Save(&profile).Error
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = p.logService.AddLog(entities.ActivityLog{
+	p.logService.AddLog(entities.ActivityLog{
 		UserID:        user.ID,
 		ActionType:    "Update Profile",
 		ActionDetails: fmt.Sprintf("User %s Updating Profile with ID %d", user.Username, profile.ID),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.UpdateProfileResponse{
-		Status: "Successfully updated profile",
-		Profile: &pb.Profile{
-			Id:     uint32(profile.ID),
-			UserId: uint32(profile.UserID),
-			Age:    int32(profile.Age),
-			Bio:    profile.Bio,
-			Photos: profile.Photos,
-		},
+		Status:  "Successfully updated profile",
+		Profile: toPbProfile(profile),
 	}, nil
 }
 
 func (p *ProfileHandler) DeleteProfile(ctx context.Context, req *pb.DeleteProfileRequest) (*pb.DeleteProfileResponse, error) {
-	// validate token and get user
-	user, err := p.userService.ValidateAndGetUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "invalid token '%s'", err.Error())
-	}
-
-	//validate the field
-	if req.Id == 0 {
-		return nil, errors.New("id is required")
-	}
+	// the caller is authenticated by the auth interceptor
+	user, _ := services.UserFromContext(ctx)
 
-	err = p.db.Where("id = ?", req.Id).Delete(&models.Profile{}).Error
+	err := p.db.Where("id = ?", req.Id).Delete(&models.Profile{}).Error
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = p.logService.AddLog(entities.ActivityLog{
+	p.logService.AddLog(entities.ActivityLog{
 		UserID:        user.ID,
 		ActionType:    "Delete Profile",
 		ActionDetails: fmt.Sprintf("User %s Deleting Profile with ID %d", user.Username, req.Id),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.DeleteProfileResponse{
 		Status: "Successfully deleted profile",
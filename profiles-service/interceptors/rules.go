@@ -0,0 +1,52 @@
+package interceptors
+
+import (
+	"context"
+	"contracts/auth"
+	"contracts/pb"
+	"errors"
+	"profiles-service/entities"
+	"profiles-service/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// ProfileRules is the policy table of the ProfileService RPCs. Profiles
+// can only be changed by their owner, moderators or admins, the other RPCs
+// only need an authenticated caller.
+func ProfileRules(db *gorm.DB) map[string]auth.Rule[*entities.User] {
+	ownsProfile := func(profileID uint32, user *entities.User) (bool, error) {
+		var profile models.Profile
+		err := db.Where("id = ?", profileID).First(&profile).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, status.Errorf(codes.NotFound, "profile not found")
+			}
+			return false, err
+		}
+		return profile.UserID == user.ID, nil
+	}
+
+	return map[string]auth.Rule[*entities.User]{
+		pb.ProfileService_GetProfilesSuggestion_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return req.(*pb.GetProfilesSuggestionRequest).UserId == uint32(user.ID), nil
+			},
+		},
+		pb.ProfileService_UpdateProfile_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return ownsProfile(req.(*pb.UpdateProfileRequest).Id, user)
+			},
+		},
+		pb.ProfileService_DeleteProfile_FullMethodName: {
+			Roles: []string{entities.RoleModerator, entities.RoleAdmin},
+			Owner: func(ctx context.Context, req any, user *entities.User) (bool, error) {
+				return ownsProfile(req.(*pb.DeleteProfileRequest).Id, user)
+			},
+		},
+	}
+}
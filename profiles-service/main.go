@@ -1,14 +1,20 @@
 package main
 
 import (
+	"contracts/auth"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"contracts/validate"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
 	"profiles-service/configs"
 	"profiles-service/handlers"
-	pb "profiles-service/pb/generated"
+	"profiles-service/interceptors"
 	"profiles-service/services"
+	"syscall"
 
 	"google.golang.org/grpc"
 )
@@ -21,7 +27,11 @@ func main() {
 	userService := services.NewUserService()
 	profileHandler := handlers.NewProfileHandler(db, userService, logService)
 
-	grpcServer := grpc.NewServer()
+	authInterceptor := auth.NewInterceptor(userService, interceptors.ProfileRules(db))
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rpcerr.UnaryServerInterceptor, authInterceptor.Unary, validate.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(rpcerr.StreamServerInterceptor, authInterceptor.Stream, validate.StreamServerInterceptor),
+	)
 
 	pb.RegisterProfileServiceServer(grpcServer, profileHandler)
 
@@ -37,8 +47,22 @@ func main() {
 	}
 
 	log.Printf("server listening at %s", listen.Addr().String())
+	go stopOnSignal(grpcServer)
 	if err := grpcServer.Serve(listen); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 
+	// deliver the activity logs still queued
+	logService.Close()
+}
+
+// stopOnSignal stops the server gracefully on SIGINT or SIGTERM, letting
+// the running calls finish.
+func stopOnSignal(grpcServer *grpc.Server) {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	log.Printf("shutting down")
+	grpcServer.GracefulStop()
 }
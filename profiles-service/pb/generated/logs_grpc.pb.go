@@ -1,211 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.28.0
-// source: logs.proto
-
-package pb
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
-
-const (
-	LogService_AddLog_FullMethodName     = "/logs_grpc.LogService/AddLog"
-	LogService_GetLogs_FullMethodName    = "/logs_grpc.LogService/GetLogs"
-	LogService_StreamLogs_FullMethodName = "/logs_grpc.LogService/StreamLogs"
-)
-
-// LogServiceClient is the client API for LogService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-//
-// The log service definition
-type LogServiceClient interface {
-	// Add a new activity log
-	AddLog(ctx context.Context, in *AddLogRequest, opts ...grpc.CallOption) (*AddLogResponse, error)
-	// Get activity logs for a specific user
-	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error)
-	// Stream activity logs in real-time
-	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamLogsResponse], error)
-}
-
-type logServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewLogServiceClient(cc grpc.ClientConnInterface) LogServiceClient {
-	return &logServiceClient{cc}
-}
-
-func (c *logServiceClient) AddLog(ctx context.Context, in *AddLogRequest, opts ...grpc.CallOption) (*AddLogResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AddLogResponse)
-	err := c.cc.Invoke(ctx, LogService_AddLog_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *logServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetLogsResponse)
-	err := c.cc.Invoke(ctx, LogService_GetLogs_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *logServiceClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamLogsResponse], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &LogService_ServiceDesc.Streams[0], LogService_StreamLogs_FullMethodName, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &grpc.GenericClientStream[StreamLogsRequest, StreamLogsResponse]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type LogService_StreamLogsClient = grpc.ServerStreamingClient[StreamLogsResponse]
-
-// LogServiceServer is the server API for LogService service.
-// All implementations must embed UnimplementedLogServiceServer
-// for forward compatibility.
-//
-// The log service definition
-type LogServiceServer interface {
-	// Add a new activity log
-	AddLog(context.Context, *AddLogRequest) (*AddLogResponse, error)
-	// Get activity logs for a specific user
-	GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error)
-	// Stream activity logs in real-time
-	StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[StreamLogsResponse]) error
-	mustEmbedUnimplementedLogServiceServer()
-}
-
-// UnimplementedLogServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedLogServiceServer struct{}
-
-func (UnimplementedLogServiceServer) AddLog(context.Context, *AddLogRequest) (*AddLogResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddLog not implemented")
-}
-func (UnimplementedLogServiceServer) GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
-}
-func (UnimplementedLogServiceServer) StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[StreamLogsResponse]) error {
-	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
-}
-func (UnimplementedLogServiceServer) mustEmbedUnimplementedLogServiceServer() {}
-func (UnimplementedLogServiceServer) testEmbeddedByValue()                    {}
-
-// UnsafeLogServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to LogServiceServer will
-// result in compilation errors.
-type UnsafeLogServiceServer interface {
-	mustEmbedUnimplementedLogServiceServer()
-}
-
-func RegisterLogServiceServer(s grpc.ServiceRegistrar, srv LogServiceServer) {
-	// If the following call pancis, it indicates UnimplementedLogServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
-	s.RegisterService(&LogService_ServiceDesc, srv)
-}
-
-func _LogService_AddLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddLogRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LogServiceServer).AddLog(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: LogService_AddLog_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LogServiceServer).AddLog(ctx, req.(*AddLogRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _LogService_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetLogsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LogServiceServer).GetLogs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: LogService_GetLogs_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LogServiceServer).GetLogs(ctx, req.(*GetLogsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _LogService_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StreamLogsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(LogServiceServer).StreamLogs(m, &grpc.GenericServerStream[StreamLogsRequest, StreamLogsResponse]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type LogService_StreamLogsServer = grpc.ServerStreamingServer[StreamLogsResponse]
-
-// LogService_ServiceDesc is the grpc.ServiceDesc for LogService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var LogService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "logs_grpc.LogService",
-	HandlerType: (*LogServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddLog",
-			Handler:    _LogService_AddLog_Handler,
-		},
-		{
-			MethodName: "GetLogs",
-			Handler:    _LogService_GetLogs_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "StreamLogs",
-			Handler:       _LogService_StreamLogs_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "logs.proto",
-}
@@ -0,0 +1,103 @@
+// Command mock-oidc is a minimal OpenID Connect issuer for trying out and
+// testing LoginWithOIDC locally. It signs ID tokens for whatever claims it
+// is asked for, never expose it.
+//
+//	go run ./cmd/mock-oidc -addr :9000
+//	curl 'localhost:9000/token?sub=123&email=jane@example.com'
+//
+// Configure users-service with
+//
+//	OIDC_PROVIDERS=[{"name":"mock","issuer":"http://localhost:9000","client_id":"mock-client"}]
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"log"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "mock-key"
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL, must match OIDC_PROVIDERS")
+	clientID := flag.String("client-id", "mock-client", "audience of the issued tokens")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	http.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                *issuer,
+			"jwks_uri":                              *issuer + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+
+	http.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"keys": []map[string]string{{
+				"kid": keyID,
+				"kty": "RSA",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	// /token?sub=...&email=...[&email_verified=false][&name=...][&nonce=...]
+	http.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sub") == "" {
+			http.Error(w, "sub is required", http.StatusBadRequest)
+			return
+		}
+
+		now := time.Now()
+		claims := jwt.MapClaims{
+			"iss":            *issuer,
+			"aud":            *clientID,
+			"sub":            q.Get("sub"),
+			"email":          q.Get("email"),
+			"email_verified": q.Get("email_verified") != "false",
+			"iat":            now.Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+		}
+		if name := q.Get("name"); name != "" {
+			claims["name"] = name
+		}
+		if nonce := q.Get("nonce"); nonce != "" {
+			claims["nonce"] = nonce
+		}
+
+		t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		t.Header["kid"] = keyID
+		s, err := t.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]string{"id_token": s})
+	})
+
+	log.Printf("mock OIDC issuer %s listening at %s", *issuer, *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
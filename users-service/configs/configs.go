@@ -1,10 +1,13 @@
 package configs
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"users-service/models"
+	"users-service/services"
+	"users-service/utils"
 
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
@@ -26,14 +29,107 @@ func CreateDBInstance() *gorm.DB {
 		os.Getenv("DB_PORT"),
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// unique violations are returned as gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	err = db.AutoMigrate(&models.User{})
+	err = db.AutoMigrate(&models.User{}, &models.LoginThrottle{}, &models.RecoveryCode{}, &models.UserIdentity{}, &models.Session{}, &models.PhoneVerification{}, &models.UserSettings{})
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	normalizeEmails(db)
+	verifyProviderAccounts(db)
+	seedAdmin(db)
 	return db
 }
+
+// LoadOIDCProviders reads the identity providers users can sign in with from
+// OIDC_PROVIDERS, a JSON array such as
+//
+//	[{"name":"google","issuer":"https://accounts.google.com","client_id":"..."}]
+func LoadOIDCProviders() []services.OIDCProvider {
+	providers := []services.OIDCProvider{}
+
+	raw := os.Getenv("OIDC_PROVIDERS")
+	if raw == "" {
+		return providers
+	}
+
+	err := json.Unmarshal([]byte(raw), &providers)
+	if err != nil {
+		log.Fatalf("invalid OIDC_PROVIDERS: %v", err)
+	}
+	for _, p := range providers {
+		if p.Name == "" || p.Issuer == "" || p.ClientID == "" {
+			log.Fatalf("invalid OIDC_PROVIDERS: name, issuer and client_id are required")
+		}
+	}
+	return providers
+}
+
+// normalizeEmails stores the emails saved before they were normalized in
+// their normalized form, unless another account already uses it.
+func normalizeEmails(db *gorm.DB) {
+	err := db.Exec(`UPDATE users SET email = LOWER(TRIM(email))
+		WHERE email <> LOWER(TRIM(email))
+		AND NOT EXISTS (SELECT 1 FROM users other WHERE other.email = LOWER(TRIM(users.email)))`).Error
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// verifyProviderAccounts marks the accounts created from a provider login
+// before they were created verified, the provider vouched for their email.
+func verifyProviderAccounts(db *gorm.DB) {
+	err := db.Exec(`UPDATE users SET is_verified = true
+		WHERE is_verified = false AND password_hash = ''
+		AND EXISTS (SELECT 1 FROM user_identities WHERE user_identities.user_id = users.id)`).Error
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// seedAdmin creates the bootstrap admin account from ADMIN_EMAIL and
+// ADMIN_PASSWORD, or promotes the existing account with that email.
+func seedAdmin(db *gorm.DB) {
+	email := utils.NormalizeEmail(os.Getenv("ADMIN_EMAIL"))
+	password := os.Getenv("ADMIN_PASSWORD")
+	if email == "" || password == "" {
+		return
+	}
+
+	var admin models.User
+	err := db.Where("email = ?", email).First(&admin).Error
+	if err == nil {
+		if admin.Role != models.RoleAdmin {
+			err = db.Model(&admin).Update("role", models.RoleAdmin).Error
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		return
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	admin = models.User{
+		Email:        email,
+		Username:     "admin",
+		PasswordHash: hash,
+		IsVerified:   true,
+		Role:         models.RoleAdmin,
+	}
+	err = db.Create(&admin).Error
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("created admin account %s", email)
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+	"users-service/entities"
+	"users-service/models"
+	"users-service/services"
+	"users-service/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// mfaClaim marks the challenge tokens Login hands out to users with
+// two-factor authentication, they are only accepted by VerifyMFA
+const mfaClaim = "mfa"
+
+// how long a user has to enter their second factor after the password
+const mfaTokenTTL = 5 * time.Minute
+
+func signMFAToken(user *models.User) (string, error) {
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":     user.ID,
+		"email":  user.Email,
+		mfaClaim: true,
+		"exp":    time.Now().Add(mfaTokenTTL).Unix(),
+	})
+	return t.SignedString([]byte(os.Getenv("JWT_SECRET")))
+}
+
+// parseMFAToken returns the email of the user a challenge token was issued to.
+func parseMFAToken(token string) (string, error) {
+	t, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || !t.Valid {
+		return "", status.Errorf(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok || claims[mfaClaim] != true {
+		return "", status.Errorf(codes.Unauthenticated, "invalid mfa token")
+	}
+
+	email, ok := claims["email"].(string)
+	if !ok || email == "" {
+		return "", status.Errorf(codes.Unauthenticated, "invalid mfa token")
+	}
+	return email, nil
+}
+
+func totpIssuer() string {
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = "dating-apps"
+	}
+	return issuer
+}
+
+// checkSecondFactor verifies a TOTP code, or an unused recovery code when
+// allowRecovery is set, and burns it so it can't be replayed. Codes are
+// burnt by conditional updates, of two concurrent requests with the same
+// code only one passes.
+func (u *UserHandler) checkSecondFactor(user *models.User, code string, allowRecovery bool) (bool, error) {
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if ok {
+		if step <= user.TOTPLastStep {
+			return false, nil
+		}
+
+		res := u.db.Model(&models.User{}).
+			Where("id = ? AND totp_last_step < ?", user.ID, step).
+			Update("totp_last_step", step)
+		if res.Error != nil || res.RowsAffected != 1 {
+			return false, res.Error
+		}
+		user.TOTPLastStep = step
+		return true, nil
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+
+	var recoveryCode models.RecoveryCode
+	err := u.db.Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.ID, utils.HashRecoveryCode(code)).First(&recoveryCode).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	now := time.Now()
+	res := u.db.Model(&models.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", recoveryCode.ID).
+		Update("used_at", &now)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// replaceRecoveryCodes drops the user's recovery codes and returns new ones.
+func replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes(utils.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.RecoveryCode, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		rows = append(rows, models.RecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashRecoveryCode(code),
+		})
+	}
+
+	err = tx.Create(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// callerFromDB reloads the authenticated caller so the TOTP fields are fresh.
+func (u *UserHandler) callerFromDB(ctx context.Context) (*models.User, error) {
+	// the caller is always set, the self service RPCs are guarded by the auth
+	// interceptor
+	caller, _ := services.UserFromContext(ctx)
+
+	var user models.User
+	err := u.db.Where("id = ?", caller.ID).First(&user).Error
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return &user, nil
+}
+
+func (u *UserHandler) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.LoginUserResponse, error) {
+	email, err := parseMFAToken(req.MfaToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%s", err.Error())
+	}
+
+	// guessing codes counts as failed logins as well
+	ip := clientIP(ctx)
+	wait, err := u.loginGuard.Check(email, ip)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many failed login attempts, try again in %s", wait.Round(time.Second))
+	}
+
+	var user models.User
+	err = u.db.Where("email = ?", email).First(&user).Error
+	if err != nil || !user.TOTPEnabled {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid mfa token")
+	}
+
+	ok, err := u.checkSecondFactor(&user, req.Code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		err = u.failLogin(email, ip, &user)
+		if err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid code")
+	}
+
+	return u.completeLogin(ctx, &user)
+}
+
+func (u *UserHandler) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	// enrolling again before confirming simply replaces the pending secret
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.db.Model(user).Updates(map[string]any{
+		"totp_secret":    secret,
+		"totp_last_step": 0,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: utils.TOTPURI(totpIssuer(), user.Email, secret),
+	}, nil
+}
+
+func (u *UserHandler) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication enrolment was not started")
+	}
+
+	ok, err := u.checkSecondFactor(user, req.Code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, rpcerr.InvalidField("code", "invalid code")
+	}
+
+	var recoveryCodes []string
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Update("totp_enabled", true).Error
+		if err != nil {
+			return err
+		}
+
+		recoveryCodes, err = replaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Enable 2FA",
+		ActionDetails: fmt.Sprintf("User %s enabled two-factor authentication", user.Username),
+	})
+
+	return &pb.ConfirmTOTPResponse{
+		Status:        "Two-Factor Authentication Enabled",
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (u *UserHandler) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	ok, err := u.checkSecondFactor(user, req.Code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, rpcerr.InvalidField("code", "invalid code")
+	}
+
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Updates(map[string]any{
+			"totp_secret":    "",
+			"totp_enabled":   false,
+			"totp_last_step": 0,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.RecoveryCode{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Disable 2FA",
+		ActionDetails: fmt.Sprintf("User %s disabled two-factor authentication", user.Username),
+	})
+
+	return &pb.DisableTOTPResponse{
+		Status: "Two-Factor Authentication Disabled",
+	}, nil
+}
+
+func (u *UserHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RegenerateRecoveryCodesResponse, error) {
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	ok, err := u.checkSecondFactor(user, req.Code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, rpcerr.InvalidField("code", "invalid code")
+	}
+
+	recoveryCodes, err := replaceRecoveryCodes(u.db, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Regenerate Recovery Codes",
+		ActionDetails: fmt.Sprintf("User %s regenerated their recovery codes", user.Username),
+	})
+
+	return &pb.RegenerateRecoveryCodesResponse{
+		Status:        "Recovery Codes Regenerated",
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
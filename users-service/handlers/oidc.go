@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"errors"
+	"fmt"
+	"strings"
+	"users-service/entities"
+	"users-service/models"
+	"users-service/services"
+	"users-service/utils"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (u *UserHandler) LoginWithOIDC(ctx context.Context, req *pb.OIDCLoginRequest) (*pb.LoginUserResponse, error) {
+	claims, err := u.oidcVerifier.Verify(ctx, req.Provider, req.IdToken, req.Nonce)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownProvider) {
+			return nil, rpcerr.InvalidField("provider", fmt.Sprintf("unknown provider '%s'", req.Provider))
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "%s", err.Error())
+	}
+
+	user, err := u.userForIdentity(req.Provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.firstFactorPassed(ctx, user)
+}
+
+// userForIdentity returns the user linked to the provider account. Unknown
+// provider accounts are linked to the user with the same email when both
+// the provider and the user verified it, or to a new user when there is
+// none. Emails are registered without proof of ownership, linking to an
+// unverified account would leave its password to whoever registered it.
+func (u *UserHandler) userForIdentity(provider string, claims *services.OIDCClaims) (*models.User, error) {
+	var user models.User
+
+	var identity models.UserIdentity
+	err := u.db.Where("provider = ? AND subject = ?", provider, claims.Subject).First(&identity).Error
+	if err == nil {
+		err = u.db.Where("id = ?", identity.UserID).First(&user).Error
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return &user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// linking by email is only safe when the provider vouches for it
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, status.Errorf(codes.Unauthenticated, "the provider did not return a verified email")
+	}
+
+	created := false
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("email = ?", claims.Email).First(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// accounts created this way have no password, they can only
+			// sign in through their providers, which verified the email
+			user = models.User{
+				Email:      claims.Email,
+				Username:   oidcUsername(claims),
+				Role:       models.RoleUser,
+				IsVerified: true,
+			}
+			err = tx.Create(&user).Error
+			if err != nil {
+				return err
+			}
+
+			settings := models.DefaultUserSettings(user.ID)
+			err = tx.Create(&settings).Error
+			created = true
+		}
+		if err != nil {
+			return err
+		}
+		if !created && !user.IsVerified {
+			return rpcerr.WithReason(codes.FailedPrecondition, "ACCOUNT_NOT_VERIFIED", "an account with an unverified email already uses %s, sign in with its password", claims.Email)
+		}
+
+		return tx.Create(&models.UserIdentity{
+			UserID:   user.ID,
+			Provider: provider,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		u.logService.AddLog(entities.ActivityLog{
+			UserID:        user.ID,
+			ActionType:    "Register",
+			ActionDetails: fmt.Sprintf("User %s registered with %s", user.Username, provider),
+		})
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Link Identity",
+		ActionDetails: fmt.Sprintf("User %s linked their %s account", user.Username, provider),
+	})
+
+	return &user, nil
+}
+
+// maxUsernameLength is the size of the username column
+const maxUsernameLength = 100
+
+func oidcUsername(claims *services.OIDCClaims) string {
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+	return utils.Truncate(name, maxUsernameLength)
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+	"users-service/entities"
+	"users-service/models"
+	"users-service/utils"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// phone verification limits, SMS cost money and codes are short
+const (
+	phoneCodeDigits      = 6
+	phoneCodeTTL         = 10 * time.Minute
+	phoneCodeMaxAttempts = 5
+	phoneCodeResendAfter = time.Minute
+	phoneCodeMaxPerHour  = 5
+)
+
+// errPhoneCodeUsed is returned when a concurrent request redeemed the code
+var errPhoneCodeUsed = errors.New("phone verification code already used")
+
+func errPhoneTaken() error {
+	return rpcerr.WithReason(codes.AlreadyExists, "PHONE_TAKEN", "phone number is already used by another account")
+}
+
+// phoneInUse reports whether another user already verified the number.
+func (u *UserHandler) phoneInUse(phone string, userID uint) (bool, error) {
+	var count int64
+	err := u.db.Model(&models.User{}).Where("phone = ? AND phone_verified AND id != ?", phone, userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (u *UserHandler) SendPhoneCode(ctx context.Context, req *pb.SendPhoneCodeRequest) (*pb.SendPhoneCodeResponse, error) {
+	phone, err := utils.NormalizePhone(req.Phone)
+	if err != nil {
+		return nil, rpcerr.InvalidField("phone", err.Error())
+	}
+
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse, err := u.phoneInUse(phone, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, errPhoneTaken()
+	}
+
+	code, err := utils.GenerateOTP(phoneCodeDigits)
+	if err != nil {
+		return nil, err
+	}
+
+	// the limits are checked and counted under a row lock, so that
+	// concurrent requests can't send more codes than allowed
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).
+			Create(&models.PhoneVerification{UserID: user.ID, Phone: phone}).Error
+		if err != nil {
+			return err
+		}
+
+		var verification models.PhoneVerification
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", user.ID).First(&verification).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if wait := verification.LastSentAt.Add(phoneCodeResendAfter).Sub(now); wait > 0 {
+			return status.Errorf(codes.ResourceExhausted, "wait %s before requesting another code", wait.Round(time.Second))
+		}
+		if now.Sub(verification.WindowStart) > time.Hour {
+			verification.WindowStart = now
+			verification.SentCount = 0
+		}
+		if verification.SentCount >= phoneCodeMaxPerHour {
+			return status.Errorf(codes.ResourceExhausted, "too many codes requested, try again later")
+		}
+
+		verification.Phone = phone
+		verification.CodeHash = utils.HashOTP(code)
+		verification.ExpiresAt = now.Add(phoneCodeTTL)
+		verification.Attempts = 0
+		verification.SentCount++
+		verification.LastSentAt = now
+		return tx.Save(&verification).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(phoneCodeTTL.Minutes()))
+	err = u.smsSender.Send(ctx, phone, message)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to send SMS: %s", err.Error())
+	}
+
+	return &pb.SendPhoneCodeResponse{
+		Status:           "Verification Code Sent",
+		ExpiresInSeconds: int32(phoneCodeTTL.Seconds()),
+	}, nil
+}
+
+func (u *UserHandler) VerifyPhone(ctx context.Context, req *pb.VerifyPhoneRequest) (*pb.VerifyPhoneResponse, error) {
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var verification models.PhoneVerification
+	err = u.db.Where("user_id = ?", user.ID).First(&verification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "no verification code was requested")
+		}
+		return nil, err
+	}
+
+	if verification.CodeHash == "" || time.Now().After(verification.ExpiresAt) {
+		return nil, status.Errorf(codes.FailedPrecondition, "verification code has expired, request a new one")
+	}
+
+	// the attempt is counted before the code is compared, so that guesses
+	// sent in parallel can't get past the limit
+	res := u.db.Model(&models.PhoneVerification{}).
+		Where("id = ? AND attempts < ? AND code_hash <> ''", verification.ID, phoneCodeMaxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many wrong codes, request a new one")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashOTP(req.Code)), []byte(verification.CodeHash)) != 1 {
+		return nil, rpcerr.InvalidField("code", "invalid code")
+	}
+
+	// somebody else may have verified the number since the code was sent
+	inUse, err := u.phoneInUse(verification.Phone, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, errPhoneTaken()
+	}
+
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		// the code is single use, the send counters are kept
+		res := tx.Model(&models.PhoneVerification{}).
+			Where("id = ? AND code_hash = ?", verification.ID, verification.CodeHash).
+			Update("code_hash", "")
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return errPhoneCodeUsed
+		}
+
+		return tx.Model(user).Updates(map[string]any{
+			"phone":          verification.Phone,
+			"phone_verified": true,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// verified by another account since the check above
+		return nil, errPhoneTaken()
+	}
+	if errors.Is(err, errPhoneCodeUsed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "verification code was already used, request a new one")
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.Phone = verification.Phone
+	user.PhoneVerified = true
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Verify Phone",
+		ActionDetails: fmt.Sprintf("User %s verified phone number %s", user.Username, verification.Phone),
+	})
+
+	return &pb.VerifyPhoneResponse{
+		Status: "Phone Number Verified",
+		User: &pb.User{
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
+		},
+	}, nil
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"contracts/pb"
+	"errors"
+	"fmt"
+	"time"
+	"users-service/entities"
+	"users-service/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (u *UserHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	// the caller is always set, ListSessions is guarded by the auth interceptor
+	caller, _ := services.UserFromContext(ctx)
+
+	sessions, err := u.sessionService.List(caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTokenID := services.TokenIDFromContext(ctx)
+	converted := make([]*pb.Session, 0)
+	for _, session := range sessions {
+		converted = append(converted, &pb.Session{
+			Id:         uint32(session.ID),
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+			Current:    currentTokenID != "" && session.TokenID == currentTokenID,
+		})
+	}
+
+	return &pb.ListSessionsResponse{
+		Sessions: converted,
+	}, nil
+}
+
+func (u *UserHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	// the caller is always set, RevokeSession is guarded by the auth interceptor
+	caller, _ := services.UserFromContext(ctx)
+
+	// only the caller's own sessions can be found
+	session, err := u.sessionService.Revoke(caller.ID, uint(req.Id))
+	if err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, err
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        caller.ID,
+		ActionType:    "Revoke Session",
+		ActionDetails: fmt.Sprintf("User %s revoked session %d (%s, %s)", caller.Username, session.ID, session.UserAgent, session.IP),
+	})
+
+	return &pb.RevokeSessionResponse{
+		Status: "Session Revoked Successfully",
+	}, nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"fmt"
+	"time"
+	"users-service/entities"
+	"users-service/models"
+	"users-service/services"
+
+	"gorm.io/gorm"
+)
+
+// settingsFor returns the settings of the user, users registered before
+// settings existed get the defaults.
+func settingsFor(db *gorm.DB, userID uint) (*models.UserSettings, error) {
+	settings := models.DefaultUserSettings(userID)
+	err := db.Where("user_id = ?", userID).FirstOrCreate(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func toPbSettings(settings *models.UserSettings) *pb.Settings {
+	return &pb.Settings{
+		Notifications: &pb.NotificationSettings{
+			NewMatch:        &pb.NotificationChannels{Push: settings.NewMatchPush, Email: settings.NewMatchEmail},
+			NewMessage:      &pb.NotificationChannels{Push: settings.NewMessagePush, Email: settings.NewMessageEmail},
+			Likes:           &pb.NotificationChannels{Push: settings.LikesPush, Email: settings.LikesEmail},
+			PaymentReceipts: &pb.NotificationChannels{Push: settings.PaymentReceiptsPush, Email: settings.PaymentReceiptsEmail},
+		},
+		DistanceUnit: settings.DistanceUnit,
+		Language:     settings.Language,
+		Timezone:     settings.Timezone,
+	}
+}
+
+// applyChannels copies the channels set in update to push and email.
+func applyChannels(update *pb.NotificationChannelsUpdate, push, email *bool) {
+	if update == nil {
+		return
+	}
+	if update.Push != nil {
+		*push = *update.Push
+	}
+	if update.Email != nil {
+		*email = *update.Email
+	}
+}
+
+func (u *UserHandler) GetSettings(ctx context.Context, req *pb.GetSettingsRequest) (*pb.SettingsResponse, error) {
+	// the caller is always set, GetSettings is guarded by the auth interceptor
+	caller, _ := services.UserFromContext(ctx)
+
+	settings, err := settingsFor(u.db, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SettingsResponse{
+		Settings: toPbSettings(settings),
+	}, nil
+}
+
+func (u *UserHandler) UpdateSettings(ctx context.Context, req *pb.UpdateSettingsRequest) (*pb.SettingsResponse, error) {
+	// distance_unit and language are checked against the rules of the
+	// contract, time zones need the tz database
+	if req.Timezone != nil {
+		// LoadLocation also accepts "" and "Local", which mean the server's zone
+		_, err := time.LoadLocation(*req.Timezone)
+		if err != nil || *req.Timezone == "" || *req.Timezone == "Local" {
+			return nil, rpcerr.InvalidField("timezone", fmt.Sprintf("invalid timezone '%s'", *req.Timezone))
+		}
+	}
+
+	// the caller is always set, UpdateSettings is guarded by the auth interceptor
+	caller, _ := services.UserFromContext(ctx)
+
+	settings, err := settingsFor(u.db, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if n := req.Notifications; n != nil {
+		applyChannels(n.NewMatch, &settings.NewMatchPush, &settings.NewMatchEmail)
+		applyChannels(n.NewMessage, &settings.NewMessagePush, &settings.NewMessageEmail)
+		applyChannels(n.Likes, &settings.LikesPush, &settings.LikesEmail)
+		applyChannels(n.PaymentReceipts, &settings.PaymentReceiptsPush, &settings.PaymentReceiptsEmail)
+	}
+
+	if req.DistanceUnit != nil {
+		settings.DistanceUnit = *req.DistanceUnit
+	}
+
+	if req.Language != nil {
+		settings.Language = *req.Language
+	}
+
+	if req.Timezone != nil {
+		settings.Timezone = *req.Timezone
+	}
+
+	err = u.db.Save(settings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        caller.ID,
+		ActionType:    "Update Settings",
+		ActionDetails: fmt.Sprintf("User %s updated their settings", caller.Username),
+	})
+
+	return &pb.SettingsResponse{
+		Settings: toPbSettings(settings),
+	}, nil
+}
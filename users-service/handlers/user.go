@@ -2,44 +2,91 @@ package handlers
 
 import (
 	"context"
+	"contracts/pb"
+	"contracts/rpcerr"
 	"errors"
 	"fmt"
+	"log"
+	"net"
 	"os"
+	"time"
 	"users-service/entities"
 	"users-service/models"
-	pb "users-service/pb/generated"
 	"users-service/services"
 	"users-service/utils"
 
 	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
 type UserHandler struct {
 	pb.UnimplementedUserServiceServer
-	db         *gorm.DB
-	logService services.LogService
+	db             *gorm.DB
+	logService     services.LogService
+	loginGuard     services.LoginGuard
+	oidcVerifier   services.OIDCVerifier
+	sessionService services.SessionService
+	smsSender      services.SMSSender
 }
 
-func NewUserHandler(db *gorm.DB, logService services.LogService) *UserHandler {
+func NewUserHandler(db *gorm.DB, logService services.LogService, loginGuard services.LoginGuard, oidcVerifier services.OIDCVerifier, sessionService services.SessionService, smsSender services.SMSSender) *UserHandler {
 	return &UserHandler{
-		db:         db,
-		logService: logService,
+		db:             db,
+		logService:     logService,
+		loginGuard:     loginGuard,
+		oidcVerifier:   oidcVerifier,
+		sessionService: sessionService,
+		smsSender:      smsSender,
 	}
 }
 
-func (u *UserHandler) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	//validate requests
-	if req.Email == "" {
-		return nil, errors.New("email is required")
+// errInvalidCredentials is returned for both unknown emails and wrong
+// passwords so Login can't be used to find out which accounts exist.
+var errInvalidCredentials = status.Errorf(codes.Unauthenticated, "invalid email or password")
+
+// clientIP returns the address of the end user, forwarded by the api-gateway
+// in the client_ip metadata, or the address of the peer otherwise.
+func clientIP(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok && len(md["client_ip"]) > 0 && md["client_ip"][0] != "" {
+		return md["client_ip"][0]
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
 	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
 
-	if req.Password == "" {
-		return nil, errors.New("password is required")
+// userAgent returns the user agent of the end user, forwarded by the
+// api-gateway in the user_agent metadata, or the one of the gRPC client.
+func userAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
 	}
+	if len(md["user_agent"]) > 0 && md["user_agent"][0] != "" {
+		return md["user_agent"][0]
+	}
+	if len(md["user-agent"]) > 0 {
+		return md["user-agent"][0]
+	}
+	return ""
+}
 
-	if req.Username == "" {
-		return nil, errors.New("username is required")
+func (u *UserHandler) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	err := utils.ValidatePassword(req.Password, req.Email, req.Username)
+	if err != nil {
+		return nil, rpcerr.InvalidField("password", err.Error())
 	}
 
 	//hash password
@@ -50,58 +97,159 @@ func (u *UserHandler) Register(ctx context.Context, req *pb.CreateUserRequest) (
 
 	// create new user
 	user := models.User{
-		Email:        req.Email,
+		Email:        utils.NormalizeEmail(req.Email),
 		PasswordHash: hash,
 		Username:     req.Username,
 		IsPremium:    false,
 		IsVerified:   false,
+		Role:         models.RoleUser,
 	}
 
-	err = u.db.Create(&user).Error
+	// every user starts with the default settings
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&user).Error
+		if err != nil {
+			return err
+		}
+
+		settings := models.DefaultUserSettings(user.ID)
+		return tx.Create(&settings).Error
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = u.logService.AddLog(entities.ActivityLog{
+	u.logService.AddLog(entities.ActivityLog{
 		UserID:        user.ID,
 		ActionType:    "Register",
 		ActionDetails: fmt.Sprintf("User %s registered", user.Username),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.CreateUserResponse{
 		Status: "User Created Successfully",
 		User: &pb.User{
-			Id:         uint32(user.ID),
-			Email:      user.Email,
-			Username:   user.Username,
-			IsPremium:  user.IsPremium,
-			IsVerified: user.IsVerified,
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
 		},
 	}, nil
 }
 
 func (u *UserHandler) Login(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
-	//validate requests
-	if req.Email == "" {
-		return nil, errors.New("email is required")
+	// refuse the attempt while the account or the client is throttled
+	ip := clientIP(ctx)
+	wait, err := u.loginGuard.Check(req.Email, ip)
+	if err != nil {
+		return nil, err
 	}
-
-	if req.Password == "" {
-		return nil, errors.New("password is required")
+	if wait > 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many failed login attempts, try again in %s", wait.Round(time.Second))
 	}
 
 	// check if user exists
 	var user models.User
-	err := u.db.Where("email = ?", req.Email).First(&user).Error
+	err = u.db.Where("email = ?", utils.NormalizeEmail(req.Email)).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	found := err == nil
+	if !found {
+		// unknown emails cost a password check too, so the response time
+		// doesn't tell which emails are registered
+		user.PasswordHash = utils.DummyPasswordHash()
+	}
+
+	if !utils.CheckPasswordHash(req.Password, user.PasswordHash) || !found {
+		var lockable *models.User
+		if found {
+			lockable = &user
+		}
+
+		err = u.failLogin(req.Email, ip, lockable)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errInvalidCredentials
+	}
+
+	// hashes made with an older algorithm or weaker parameters are replaced
+	// while the plain password is at hand
+	if utils.NeedsRehash(user.PasswordHash) {
+		u.rehashPassword(&user, req.Password)
+	}
+
+	return u.firstFactorPassed(ctx, &user)
+}
+
+func (u *UserHandler) rehashPassword(user *models.User, password string) {
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		log.Printf("failed to rehash password of user %d: %v", user.ID, err)
+		return
+	}
+
+	err = u.db.Model(user).Update("password_hash", hash).Error
+	if err != nil {
+		log.Printf("failed to rehash password of user %d: %v", user.ID, err)
+	}
+}
+
+// firstFactorPassed issues the access token of a user who proved who they
+// are with a password or an identity provider, or an MFA challenge token
+// when they have two-factor authentication on. The failed attempts are only
+// cleared once the login completes.
+func (u *UserHandler) firstFactorPassed(ctx context.Context, user *models.User) (*pb.LoginUserResponse, error) {
+	if user.TOTPEnabled {
+		mfaToken, err := signMFAToken(user)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to sign token")
+		}
+
+		return &pb.LoginUserResponse{
+			Status:      "MFA Required",
+			MfaRequired: true,
+			MfaToken:    mfaToken,
+		}, nil
+	}
+
+	return u.completeLogin(ctx, user)
+}
+
+// failLogin records a failed login attempt and logs the lockout of user when
+// it is the one that locked the account. user is nil for unknown emails.
+func (u *UserHandler) failLogin(email, ip string, user *models.User) error {
+	locked, err := u.loginGuard.Fail(email, ip)
+	if err != nil {
+		return err
+	}
+
+	if !locked || user == nil {
+		return nil
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Account Locked",
+		ActionDetails: fmt.Sprintf("User %s locked out after too many failed login attempts from %s", user.Username, ip),
+	})
+	return nil
+}
+
+// completeLogin clears the failed attempts of a fully authenticated user,
+// starts a session for the device and issues its access token.
+func (u *UserHandler) completeLogin(ctx context.Context, user *models.User) (*pb.LoginUserResponse, error) {
+	err := u.loginGuard.Succeed(user.Email)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, err
 	}
 
-	if !utils.CheckPasswordHash(req.Password, user.PasswordHash) {
-		return nil, errors.New("invalid password")
+	session, err := u.sessionService.Create(user.ID, userAgent(ctx), clientIP(ctx))
+	if err != nil {
+		return nil, err
 	}
 
 	key := os.Getenv("JWT_SECRET")
@@ -111,120 +259,131 @@ func (u *UserHandler) Login(ctx context.Context, req *pb.LoginUserRequest) (*pb.
 		"username":   user.Username,
 		"id":         user.ID,
 		"is_premium": user.IsPremium,
+		"role":       user.Role,
+		"jti":        session.TokenID,
 	})
 
 	s, err := t.SignedString([]byte(key))
 	if err != nil {
-		return nil, errors.New("failed to sign token")
+		return nil, status.Errorf(codes.Internal, "failed to sign token")
 	}
 
-	_, err = u.logService.AddLog(entities.ActivityLog{
+	u.logService.AddLog(entities.ActivityLog{
 		UserID:        user.ID,
 		ActionType:    "Login",
 		ActionDetails: fmt.Sprintf("User %s Login succesfully", user.Username),
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	return &pb.LoginUserResponse{
 		Status: "Login Successful",
 		Token:  s,
 		User: &pb.User{
-			Id:         uint32(user.ID),
-			Email:      user.Email,
-			Username:   user.Username,
-			IsPremium:  user.IsPremium,
-			IsVerified: user.IsVerified,
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
 		},
 	}, nil
 }
 
 func (u *UserHandler) IsValidToken(ctx context.Context, req *pb.IsValidTokenRequest) (*pb.IsValidTokenResponse, error) {
-	//validate requests
-	if req.Token == "" {
-		return nil, errors.New("token is required")
-	}
 	fmt.Printf("Token diterima di user service handler :%s\n", req.Token)
 	key := os.Getenv("JWT_SECRET")
 	token, err := jwt.Parse(req.Token, func(token *jwt.Token) (interface{}, error) {
 		return []byte(key), nil
 	})
 
-	if err != nil {
-		return nil, errors.New("invalid token")
-	}
-	if !token.Valid {
-		return nil, fmt.Errorf("invalid token '%v',", token)
+	if err != nil || !token.Valid {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
 	}
 
 	var user models.User
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return nil, errors.New("invalid token")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	// MFA challenge tokens can only be exchanged through VerifyMFA
+	if _, ok := claims[mfaClaim]; ok {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
 	}
 
 	err = u.db.Where("email = ?", claims["email"]).First(&user).Error
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+
+	// tokens of revoked sessions are refused
+	tokenID, _ := claims["jti"].(string)
+	err = u.sessionService.Touch(tokenID, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token, %s", err.Error())
 	}
 
 	res := &pb.IsValidTokenResponse{
 		Valid: true,
 		User: &pb.User{
-			Id:         uint32(user.ID),
-			Email:      user.Email,
-			Username:   user.Username,
-			IsPremium:  user.IsPremium,
-			IsVerified: user.IsVerified,
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
 		},
 	}
 	return res, nil
 }
 
 func (u *UserHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
-	//validate requests
-	if req.Id == 0 {
-		return nil, errors.New("id is required")
-	}
-
 	var user models.User
 	err := u.db.Where("id = ?", req.Id).First(&user).Error
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, status.Errorf(codes.NotFound, "user not found")
 	}
 
 	return &pb.GetUserResponse{
 		User: &pb.User{
-			Id:         uint32(user.ID),
-			Email:      user.Email,
-			Username:   user.Username,
-			IsPremium:  user.IsPremium,
-			IsVerified: user.IsVerified,
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
 		},
 	}, nil
 }
 
 func (u *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	//validate requests
-	if req.Id == 0 {
-		return nil, errors.New("id is required")
-	}
-
 	var user models.User
 	err := u.db.Where("id = ?", req.Id).First(&user).Error
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, status.Errorf(codes.NotFound, "user not found")
 	}
 
-	if req.Email != "" {
-		user.Email = req.Email
+	// a changed email has to be verified again
+	if email := utils.NormalizeEmail(req.Email); email != "" && email != user.Email {
+		user.Email = email
+		user.IsVerified = false
 	}
 
 	if req.Username != "" {
 		user.Username = req.Username
 	}
 
+	// premium and verification status can only be granted by admins or
+	// other backend services (e.g. payment-service after a payment)
+	if req.IsPremium || req.IsVerified {
+		caller, ok := services.UserFromContext(ctx)
+		if !ok || (caller.Role != models.RoleAdmin && caller.Role != models.RoleService) {
+			return nil, status.Errorf(codes.PermissionDenied, "only admins can change premium or verification status")
+		}
+	}
+
 	if req.IsPremium {
 		user.IsPremium = req.IsPremium
 	}
@@ -235,9 +394,9 @@ func (u *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 	//check if email is already used by another user and id is not the same
 	var user2 models.User
-	err = u.db.Where("email = ? AND id != ?", req.Email, req.Id).First(&user2).Error
+	err = u.db.Where("email = ? AND id != ?", user.Email, req.Id).First(&user2).Error
 	if err == nil {
-		return nil, errors.New("email already used by another user")
+		return nil, rpcerr.WithReason(codes.AlreadyExists, "EMAIL_TAKEN", "email already used by another user")
 	}
 
 	err = u.db.Save(&user).Error
@@ -248,11 +407,110 @@ func (u *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	return &pb.UpdateUserResponse{
 		Status: "User Updated Successfully",
 		User: &pb.User{
-			Id:         uint32(user.ID),
-			Email:      user.Email,
-			Username:   user.Username,
-			IsPremium:  user.IsPremium,
-			IsVerified: user.IsVerified,
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
+		},
+	}, nil
+}
+
+func (u *UserHandler) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.SetUserRoleResponse, error) {
+	var user models.User
+	err := u.db.Where("id = ?", req.Id).First(&user).Error
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	user.Role = req.Role
+	err = u.db.Save(&user).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// the caller is always set, SetUserRole is guarded by the auth interceptor
+	caller, _ := services.UserFromContext(ctx)
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Set Role",
+		ActionDetails: fmt.Sprintf("User %s role set to %s by %s", user.Username, user.Role, caller.Username),
+	})
+
+	return &pb.SetUserRoleResponse{
+		Status: "User Role Updated Successfully",
+		User: &pb.User{
+			Id:            uint32(user.ID),
+			Email:         user.Email,
+			Username:      user.Username,
+			IsPremium:     user.IsPremium,
+			IsVerified:    user.IsVerified,
+			Role:          user.Role,
+			PhoneVerified: user.PhoneVerified,
 		},
 	}, nil
 }
+
+func (u *UserHandler) UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*pb.UnlockAccountResponse, error) {
+	var user models.User
+	err := u.db.Where("id = ?", req.Id).First(&user).Error
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	err = u.loginGuard.Unlock(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// the caller is always set, UnlockAccount is guarded by the auth interceptor
+	caller, _ := services.UserFromContext(ctx)
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Account Unlocked",
+		ActionDetails: fmt.Sprintf("User %s unlocked by %s", user.Username, caller.Username),
+	})
+
+	return &pb.UnlockAccountResponse{
+		Status: "Account Unlocked Successfully",
+	}, nil
+}
+
+func (u *UserHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	user, err := u.callerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// accounts created through an identity provider have no password yet
+	if user.PasswordHash != "" && !utils.CheckPasswordHash(req.CurrentPassword, user.PasswordHash) {
+		return nil, status.Errorf(codes.PermissionDenied, "current password is incorrect")
+	}
+
+	err = utils.ValidatePassword(req.NewPassword, user.Email, user.Username)
+	if err != nil {
+		return nil, rpcerr.InvalidField("new_password", err.Error())
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.db.Model(user).Update("password_hash", hash).Error
+	if err != nil {
+		return nil, err
+	}
+
+	u.logService.AddLog(entities.ActivityLog{
+		UserID:        user.ID,
+		ActionType:    "Change Password",
+		ActionDetails: fmt.Sprintf("User %s changed their password", user.Username),
+	})
+
+	return &pb.ChangePasswordResponse{
+		Status: "Password Changed Successfully",
+	}, nil
+}
@@ -0,0 +1,53 @@
+package interceptors
+
+import (
+	"context"
+	"contracts/auth"
+	"contracts/pb"
+	"users-service/models"
+)
+
+// selfService lets any signed in user call an RPC that acts on their own
+// account. Internal services have no account of their own.
+func selfService(ctx context.Context, req any, user *models.User) (bool, error) {
+	return user.Role != models.RoleService && user.ID != 0, nil
+}
+
+// UserRules is the policy table of the UserService RPCs.
+func UserRules() map[string]auth.Rule[*models.User] {
+	return map[string]auth.Rule[*models.User]{
+		// signing up and in, and token checks by the other services
+		pb.UserService_Register_FullMethodName:      {Access: auth.Public},
+		pb.UserService_Login_FullMethodName:         {Access: auth.Public},
+		pb.UserService_LoginWithOIDC_FullMethodName: {Access: auth.Public},
+		pb.UserService_VerifyMFA_FullMethodName:     {Access: auth.Public},
+		pb.UserService_IsValidToken_FullMethodName:  {Access: auth.Public},
+
+		pb.UserService_GetUser_FullMethodName: {
+			Roles: []string{models.RoleModerator, models.RoleAdmin, models.RoleService},
+			Owner: func(ctx context.Context, req any, user *models.User) (bool, error) {
+				return req.(*pb.GetUserRequest).Id == uint32(user.ID), nil
+			},
+		},
+		pb.UserService_UpdateUser_FullMethodName: {
+			Roles: []string{models.RoleAdmin, models.RoleService},
+			Owner: func(ctx context.Context, req any, user *models.User) (bool, error) {
+				return req.(*pb.UpdateUserRequest).Id == uint32(user.ID), nil
+			},
+		},
+		pb.UserService_DeleteUser_FullMethodName:              {Access: auth.Admin},
+		pb.UserService_SetUserRole_FullMethodName:             {Access: auth.Admin},
+		pb.UserService_UnlockAccount_FullMethodName:           {Access: auth.Admin},
+		pb.UserService_EnrollTOTP_FullMethodName:              {Owner: selfService},
+		pb.UserService_ConfirmTOTP_FullMethodName:             {Owner: selfService},
+		pb.UserService_DisableTOTP_FullMethodName:             {Owner: selfService},
+		pb.UserService_RegenerateRecoveryCodes_FullMethodName: {Owner: selfService},
+		pb.UserService_ListSessions_FullMethodName:            {Owner: selfService},
+		pb.UserService_RevokeSession_FullMethodName:           {Owner: selfService},
+		pb.UserService_ChangePassword_FullMethodName:          {Owner: selfService},
+		pb.UserService_SendPhoneCode_FullMethodName:           {Owner: selfService},
+		pb.UserService_VerifyPhone_FullMethodName:             {Owner: selfService},
+		pb.UserService_GetSettings_FullMethodName:             {Owner: selfService},
+		pb.UserService_UpdateSettings_FullMethodName:          {Owner: selfService},
+	}
+}
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"contracts/auth"
+	"contracts/pb"
+	"contracts/rpcerr"
+	"contracts/validate"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
 	"users-service/configs"
 	"users-service/handlers"
-	pb "users-service/pb/generated"
+	"users-service/interceptors"
 	"users-service/services"
 
 	"google.golang.org/grpc"
@@ -18,9 +24,17 @@ func main() {
 
 	//instantiate services
 	logService := services.NewLogService()
-	userHandler := handlers.NewUserHandler(db, logService)
+	sessionService := services.NewSessionService(db)
+	userService := services.NewUserService(db, sessionService)
+	loginGuard := services.NewLoginGuard(db)
+	oidcVerifier := services.NewOIDCVerifier(configs.LoadOIDCProviders())
+	userHandler := handlers.NewUserHandler(db, logService, loginGuard, oidcVerifier, sessionService, services.NewSMSSender())
 
-	grpcServer := grpc.NewServer()
+	authInterceptor := auth.NewInterceptor(userService, interceptors.UserRules())
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rpcerr.UnaryServerInterceptor, authInterceptor.Unary, validate.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(rpcerr.StreamServerInterceptor, authInterceptor.Stream, validate.StreamServerInterceptor),
+	)
 
 	pb.RegisterUserServiceServer(grpcServer, userHandler)
 
@@ -36,8 +50,22 @@ func main() {
 	}
 
 	log.Printf("server listening at %s", listen.Addr().String())
+	go stopOnSignal(grpcServer)
 	if err := grpcServer.Serve(listen); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 
+	// deliver the activity logs still queued
+	logService.Close()
+}
+
+// stopOnSignal stops the server gracefully on SIGINT or SIGTERM, letting
+// the running calls finish.
+func stopOnSignal(grpcServer *grpc.Server) {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	log.Printf("shutting down")
+	grpcServer.GracefulStop()
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoginThrottle tracks failed login attempts for a key, either an account
+// ("account:<email>") or a client address ("ip:<addr>").
+type LoginThrottle struct {
+	gorm.Model
+	Key           string `gorm:"size:255;uniqueIndex;not null"`
+	Failures      int    `gorm:"not null;default:0"`
+	LastFailureAt *time.Time
+	BlockedUntil  *time.Time
+}
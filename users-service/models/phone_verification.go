@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PhoneVerification is the pending SMS verification of a user's phone
+// number. It also counts the codes sent so they can be rate limited.
+type PhoneVerification struct {
+	gorm.Model
+	UserID      uint   `gorm:"uniqueIndex;not null"`
+	Phone       string `gorm:"size:16;not null"`
+	CodeHash    string `gorm:"size:64;not null"`
+	ExpiresAt   time.Time
+	Attempts    int `gorm:"not null;default:0"`
+	SentCount   int `gorm:"not null;default:0"`
+	WindowStart time.Time
+	LastSentAt  time.Time
+}
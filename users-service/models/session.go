@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session is a device a user is logged in on. Every access token carries
+// the TokenID of its session in the jti claim and stops working once the
+// session is revoked.
+type Session struct {
+	gorm.Model
+	UserID     uint   `gorm:"index;not null"`
+	TokenID    string `gorm:"size:64;uniqueIndex;not null"`
+	UserAgent  string `gorm:"size:512"`
+	IP         string `gorm:"size:64"`
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+}
@@ -1,6 +1,19 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"contracts/auth"
+
+	"gorm.io/gorm"
+)
+
+// Roles a user can have. RoleService is never stored, it is assigned to
+// internal callers authenticated with the shared service token.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+	RoleService   = "service"
+)
 
 type User struct {
 	gorm.Model
@@ -9,8 +22,22 @@ type User struct {
 	PasswordHash string `gorm:"not null"`
 	IsPremium    bool   `gorm:"default:false"`
 	IsVerified   bool   `gorm:"default:false"`
+	Role         string `gorm:"type:varchar(20);not null;default:user"`
+	// TOTPSecret is set on enrolment, TOTPEnabled once it was confirmed
+	TOTPSecret   string `gorm:"size:64"`
+	TOTPEnabled  bool   `gorm:"default:false"`
+	TOTPLastStep int64  `gorm:"default:0"`
+	// Phone is in E.164 format and only set once verified by SMS, a number
+	// is verified by a single account
+	Phone         string `gorm:"size:16;index;uniqueIndex:idx_users_verified_phone,where:phone_verified = true AND deleted_at IS NULL"`
+	PhoneVerified bool   `gorm:"default:false"`
 	// Profiles     Profile `gorm:"foreignKey:UserID"`
 	// Swipes       []Swipe `gorm:"foreignKey:SwiperID"`
 	// Matches      []Match `gorm:"foreignKey:User1ID"`
 	// ActivityLogs []ActivityLog  `gorm:"foreignKey:UserID"`
 }
+
+// Identity tells the auth interceptor who the user is.
+func (u *User) Identity() auth.Identity {
+	return auth.Identity{ID: u.ID, Role: u.Role, IsPremium: u.IsPremium}
+}
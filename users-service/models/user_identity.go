@@ -0,0 +1,13 @@
+package models
+
+import "gorm.io/gorm"
+
+// UserIdentity links a user to an account at an external identity provider
+// (e.g. Google or Apple) they can sign in with.
+type UserIdentity struct {
+	gorm.Model
+	UserID   uint   `gorm:"index;not null"`
+	Provider string `gorm:"size:50;not null;uniqueIndex:idx_provider_subject"`
+	Subject  string `gorm:"size:255;not null;uniqueIndex:idx_provider_subject"`
+	Email    string `gorm:"size:255"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "gorm.io/gorm"
+
+// Distance units a user can pick.
+const (
+	DistanceUnitKilometers = "km"
+	DistanceUnitMiles      = "mi"
+)
+
+// UserSettings are the preferences of a user. Notifications are switched
+// on or off per event type and channel.
+type UserSettings struct {
+	gorm.Model
+	UserID uint `gorm:"uniqueIndex;not null"`
+
+	NewMatchPush         bool `gorm:"not null;default:true"`
+	NewMatchEmail        bool `gorm:"not null;default:true"`
+	NewMessagePush       bool `gorm:"not null;default:true"`
+	NewMessageEmail      bool `gorm:"not null;default:false"`
+	LikesPush            bool `gorm:"not null;default:true"`
+	LikesEmail           bool `gorm:"not null;default:false"`
+	PaymentReceiptsPush  bool `gorm:"not null;default:false"`
+	PaymentReceiptsEmail bool `gorm:"not null;default:true"`
+
+	DistanceUnit string `gorm:"size:2;not null;default:km"`
+	Language     string `gorm:"size:10;not null;default:en"`
+	Timezone     string `gorm:"size:64;not null;default:UTC"`
+}
+
+// DefaultUserSettings returns the settings of a new user.
+func DefaultUserSettings(userID uint) UserSettings {
+	return UserSettings{
+		UserID:               userID,
+		NewMatchPush:         true,
+		NewMatchEmail:        true,
+		NewMessagePush:       true,
+		NewMessageEmail:      false,
+		LikesPush:            true,
+		LikesEmail:           false,
+		PaymentReceiptsPush:  false,
+		PaymentReceiptsEmail: true,
+		DistanceUnit:         DistanceUnitKilometers,
+		Language:             "en",
+		Timezone:             "UTC",
+	}
+}
@@ -2,68 +2,64 @@ package services
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
+	"contracts/activity"
+	"contracts/clients"
+	"contracts/pb"
 	"log"
 	"os"
+	"time"
 	"users-service/entities"
-	pb "users-service/pb/generated"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 type LogService interface {
-	AddLog(req entities.ActivityLog) (*entities.ActivityLog, error)
+	// AddLog records an activity log in the background, it never blocks
+	// nor fails the operation it records
+	AddLog(req entities.ActivityLog)
+	// Close delivers the logs still queued, on shutdown
+	Close()
 }
 
+// closeTimeout bounds the delivery of the queued logs on shutdown, the
+// logs left are spilled or dropped.
+const closeTimeout = 10 * time.Second
+
 func NewLogClient() pb.LogServiceClient {
 	addr := os.Getenv("LOG_SERVICE_ADDR")
 
-	opts := []grpc.DialOption{}
-	systemRoots, err := x509.SystemCertPool()
-	if err != nil {
-		log.Fatal(err)
-	}
-	cred := credentials.NewTLS(&tls.Config{
-		RootCAs: systemRoots,
-	})
-	opts = append(opts, grpc.WithTransportCredentials(cred))
-	conn, err := grpc.NewClient(addr, opts...)
-	// conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	client, err := clients.NewLogClient(addr)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("did not connect: %v", err)
 	}
 
-	client := pb.NewLogServiceClient(conn)
-
 	return client
 }
 
 func NewLogService() LogService {
+	config, err := activity.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	return &logService{
-		logClient: NewLogClient(),
+		logger: activity.NewLogger(NewLogClient(), config),
 	}
 }
 
 type logService struct {
-	logClient pb.LogServiceClient
+	logger *activity.Logger
 }
 
-func (l *logService) AddLog(req entities.ActivityLog) (*entities.ActivityLog, error) {
-	res, err := l.logClient.AddLog(context.TODO(), &pb.AddLogRequest{
+func (l *logService) AddLog(req entities.ActivityLog) {
+	l.logger.Log(&pb.AddLogRequest{
 		UserId:     uint32(req.UserID),
 		ActionType: req.ActionType,
 		Details:    req.ActionDetails,
 	})
-	if err != nil {
-		return nil, err
-	}
+}
+
+func (l *logService) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
 
-	return &entities.ActivityLog{
-		ID:            uint(res.LogEntry.Id),
-		UserID:        uint(res.LogEntry.UserId),
-		ActionType:    res.LogEntry.ActionType,
-		ActionDetails: res.LogEntry.Details,
-	}, nil
+	l.logger.Close(ctx)
 }
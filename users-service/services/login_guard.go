@@ -0,0 +1,189 @@
+package services
+
+import (
+	"time"
+	"users-service/models"
+	"users-service/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ThrottlePolicy decides how long a key is blocked after failed logins.
+// The first FreeAttempts failures are not delayed, after that the delay
+// doubles from BaseDelay up to MaxDelay, and once LockoutAfter failures are
+// reached the key is locked for LockoutDuration. Failures older than Window
+// are forgotten.
+type ThrottlePolicy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAfter    int
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+var (
+	AccountThrottlePolicy = ThrottlePolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        5 * time.Minute,
+		LockoutAfter:    10,
+		LockoutDuration: 30 * time.Minute,
+		Window:          24 * time.Hour,
+	}
+	IPThrottlePolicy = ThrottlePolicy{
+		FreeAttempts:    10,
+		BaseDelay:       time.Second,
+		MaxDelay:        5 * time.Minute,
+		LockoutAfter:    50,
+		LockoutDuration: time.Hour,
+		Window:          time.Hour,
+	}
+)
+
+// blockFor returns how long to block after the given number of failures and
+// whether that block is a lockout.
+func (p ThrottlePolicy) blockFor(failures int) (time.Duration, bool) {
+	if failures >= p.LockoutAfter {
+		return p.LockoutDuration, true
+	}
+	if failures <= p.FreeAttempts {
+		return 0, false
+	}
+
+	delay := p.BaseDelay << (failures - p.FreeAttempts - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay, false
+}
+
+// LoginGuard protects Login against brute-force and credential stuffing by
+// tracking failed attempts per account and per client address.
+type LoginGuard interface {
+	// Check returns how long the caller has to wait before trying again,
+	// zero when the attempt is allowed.
+	Check(email, ip string) (time.Duration, error)
+	// Fail records a failed attempt and reports whether the account is
+	// now locked out.
+	Fail(email, ip string) (bool, error)
+	// Succeed clears the failed attempts of the account.
+	Succeed(email string) error
+	// Unlock clears the failed attempts and any lockout of the account.
+	Unlock(email string) error
+}
+
+func NewLoginGuard(db *gorm.DB) LoginGuard {
+	return &loginGuard{
+		db:      db,
+		account: AccountThrottlePolicy,
+		ip:      IPThrottlePolicy,
+	}
+}
+
+type loginGuard struct {
+	db      *gorm.DB
+	account ThrottlePolicy
+	ip      ThrottlePolicy
+}
+
+func accountKey(email string) string {
+	return "account:" + utils.NormalizeEmail(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (g *loginGuard) Check(email, ip string) (time.Duration, error) {
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+
+	var throttles []models.LoginThrottle
+	err := g.db.Where("key IN ?", keys).Find(&throttles).Error
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var wait time.Duration
+	for _, t := range throttles {
+		if t.BlockedUntil != nil && t.BlockedUntil.After(now) {
+			if d := t.BlockedUntil.Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	return wait, nil
+}
+
+func (g *loginGuard) Fail(email, ip string) (bool, error) {
+	locked, err := g.fail(accountKey(email), g.account)
+	if err != nil {
+		return false, err
+	}
+
+	if ip != "" {
+		_, err = g.fail(ipKey(ip), g.ip)
+		if err != nil {
+			return false, err
+		}
+	}
+	return locked, nil
+}
+
+// fail counts a failure of key in a transaction holding the lock of its
+// row, so that concurrent failures are all counted.
+func (g *loginGuard) fail(key string, policy ThrottlePolicy) (bool, error) {
+	var locked bool
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		// the first failures of a key may be concurrent too
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(&models.LoginThrottle{Key: key}).Error
+		if err != nil {
+			return err
+		}
+
+		var throttle models.LoginThrottle
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&throttle).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if throttle.LastFailureAt != nil && now.Sub(*throttle.LastFailureAt) > policy.Window {
+			throttle.Failures = 0
+		}
+		throttle.Failures++
+		throttle.LastFailureAt = &now
+
+		var delay time.Duration
+		delay, locked = policy.blockFor(throttle.Failures)
+		throttle.BlockedUntil = nil
+		if delay > 0 {
+			blockedUntil := now.Add(delay)
+			throttle.BlockedUntil = &blockedUntil
+		}
+
+		return tx.Save(&throttle).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (g *loginGuard) Succeed(email string) error {
+	return g.clear(accountKey(email))
+}
+
+func (g *loginGuard) Unlock(email string) error {
+	return g.clear(accountKey(email))
+}
+
+func (g *loginGuard) clear(key string) error {
+	return g.db.Unscoped().Where("key = ?", key).Delete(&models.LoginThrottle{}).Error
+}
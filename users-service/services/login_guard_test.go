@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottlePolicyBlockFor(t *testing.T) {
+	policy := ThrottlePolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        10 * time.Second,
+		LockoutAfter:    10,
+		LockoutDuration: 30 * time.Minute,
+	}
+
+	tests := []struct {
+		failures   int
+		wantDelay  time.Duration
+		wantLocked bool
+	}{
+		{failures: 0},
+		{failures: 3},
+		{failures: 4, wantDelay: time.Second},
+		{failures: 5, wantDelay: 2 * time.Second},
+		{failures: 7, wantDelay: 8 * time.Second},
+		{failures: 8, wantDelay: 10 * time.Second},
+		{failures: 9, wantDelay: 10 * time.Second},
+		{failures: 10, wantDelay: 30 * time.Minute, wantLocked: true},
+		{failures: 200, wantDelay: 30 * time.Minute, wantLocked: true},
+	}
+
+	for _, tt := range tests {
+		delay, locked := policy.blockFor(tt.failures)
+		if delay != tt.wantDelay || locked != tt.wantLocked {
+			t.Errorf("%d failures: got %v, %v, want %v, %v", tt.failures, delay, locked, tt.wantDelay, tt.wantLocked)
+		}
+	}
+
+	// the delay doesn't overflow past the maximum
+	overflowing := policy
+	overflowing.LockoutAfter = 1000
+	if delay, _ := overflowing.blockFor(200); delay != policy.MaxDelay {
+		t.Errorf("got %v after 200 failures, want %v", delay, policy.MaxDelay)
+	}
+}
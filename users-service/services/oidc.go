@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"users-service/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCProvider is an OpenID Connect identity provider users can sign in with.
+type OIDCProvider struct {
+	Name     string `json:"name"`
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"client_id"`
+}
+
+// OIDCClaims are the verified claims of an ID token used to find or create
+// the account.
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+var ErrUnknownProvider = errors.New("unknown identity provider")
+
+type OIDCVerifier interface {
+	// Verify checks the signature, issuer, audience and expiry of an ID
+	// token issued by provider, and its nonce when one is given.
+	Verify(ctx context.Context, provider, idToken, nonce string) (*OIDCClaims, error)
+}
+
+// how long discovery documents and signing keys are cached
+const jwksCacheTTL = time.Hour
+
+func NewOIDCVerifier(providers []OIDCProvider) OIDCVerifier {
+	v := &oidcVerifier{
+		client:    &http.Client{Timeout: 10 * time.Second},
+		providers: map[string]*oidcProviderKeys{},
+	}
+	for _, p := range providers {
+		p.Issuer = strings.TrimSuffix(p.Issuer, "/")
+		v.providers[p.Name] = &oidcProviderKeys{provider: p}
+	}
+	return v
+}
+
+type oidcVerifier struct {
+	client    *http.Client
+	providers map[string]*oidcProviderKeys
+}
+
+// oidcProviderKeys caches the signing keys of a provider by key id.
+type oidcProviderKeys struct {
+	provider OIDCProvider
+
+	mu        sync.Mutex
+	keys      map[string]any
+	fetchedAt time.Time
+}
+
+func (v *oidcVerifier) Verify(ctx context.Context, provider, idToken, nonce string) (*OIDCClaims, error) {
+	p, ok := v.providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, p, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384"}),
+		jwt.WithIssuer(p.provider.Issuer),
+		jwt.WithAudience(p.provider.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if nonce != "" && claims["nonce"] != nonce {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, errors.New("invalid id token: missing subject")
+	}
+
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+	return &OIDCClaims{
+		Subject:       sub,
+		Email:         utils.NormalizeEmail(email),
+		EmailVerified: isTrue(claims["email_verified"]),
+		Name:          name,
+	}, nil
+}
+
+// isTrue reads a boolean claim, some providers (e.g. Apple) send them as
+// strings.
+func isTrue(claim any) bool {
+	switch v := claim.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// key returns the signing key with the given id, refreshing the cached keys
+// when they are stale or the key is unknown (providers rotate their keys).
+func (v *oidcVerifier) key(ctx context.Context, p *oidcProviderKeys, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.keys[kid]
+	if ok && time.Since(p.fetchedAt) < jwksCacheTTL {
+		return key, nil
+	}
+
+	// don't let tokens with made up key ids hammer the provider
+	if !ok && time.Since(p.fetchedAt) < time.Minute {
+		return nil, fmt.Errorf("unknown signing key '%s'", kid)
+	}
+
+	keys, err := v.fetchKeys(ctx, p.provider)
+	if err != nil {
+		if ok {
+			// keep using the stale key rather than failing every login
+			return key, nil
+		}
+		return nil, err
+	}
+	p.keys = keys
+	p.fetchedAt = time.Now()
+
+	key, ok = p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key '%s'", kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchKeys reads the provider's discovery document and its JSON Web Key Set.
+func (v *oidcVerifier) fetchKeys(ctx context.Context, provider OIDCProvider) (map[string]any, error) {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	err := v.getJSON(ctx, provider.Issuer+"/.well-known/openid-configuration", &discovery)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != provider.Issuer {
+		return nil, fmt.Errorf("discovery issuer '%s' does not match '%s'", discovery.Issuer, provider.Issuer)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err = v.getJSON(ctx, discovery.JWKSURI, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]any{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			// skip key types we don't support
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (v *oidcVerifier) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384()}
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve '%s'", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type '%s'", k.Kty)
+}
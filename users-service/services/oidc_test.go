@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testProvider serves the discovery document and signing key of an OIDC
+// provider.
+func testProvider(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   server.URL,
+			"jwks_uri": server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []jwk{{
+				Kid: "test",
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := testProvider(t, key)
+	verifier := NewOIDCVerifier([]OIDCProvider{{Name: "test", Issuer: server.URL, ClientID: "client"}})
+
+	now := time.Now()
+	claims := func(changes jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":            server.URL,
+			"aud":            "client",
+			"sub":            "subject",
+			"email":          " Someone@Example.com",
+			"email_verified": true,
+			"iat":            now.Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+			"nonce":          "nonce",
+		}
+		for name, value := range changes {
+			if value == nil {
+				delete(c, name)
+			} else {
+				c[name] = value
+			}
+		}
+		return c
+	}
+	sign := func(method jwt.SigningMethod, signingKey any, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = "test"
+		signed, err := token.SignedString(signingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		token    string
+		nonce    string
+		wantErr  bool
+	}{
+		{name: "valid", token: sign(jwt.SigningMethodRS256, key, claims(nil)), nonce: "nonce"},
+		{name: "without nonce", token: sign(jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"nonce": nil}))},
+		{name: "unknown provider", provider: "other", token: sign(jwt.SigningMethodRS256, key, claims(nil)), wantErr: true},
+		{name: "bad issuer", token: sign(jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"iss": "https://evil.example.com"})), wantErr: true},
+		{name: "bad audience", token: sign(jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"aud": "other-client"})), wantErr: true},
+		{name: "expired", token: sign(jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})), wantErr: true},
+		{name: "no expiry", token: sign(jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"exp": nil})), wantErr: true},
+		{name: "HMAC signed", token: sign(jwt.SigningMethodHS256, []byte("client"), claims(nil)), wantErr: true},
+		{name: "unsigned", token: sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(nil)), wantErr: true},
+		{name: "other key", token: sign(jwt.SigningMethodRS256, otherKey, claims(nil)), wantErr: true},
+		{name: "nonce mismatch", token: sign(jwt.SigningMethodRS256, key, claims(nil)), nonce: "other", wantErr: true},
+		{name: "no subject", token: sign(jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"sub": nil})), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := tt.provider
+			if provider == "" {
+				provider = "test"
+			}
+			got, err := verifier.Verify(context.Background(), provider, tt.token, tt.nonce)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("the token was accepted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Subject != "subject" || got.Email != "someone@example.com" || !got.EmailVerified {
+				t.Errorf("got claims %+v", got)
+			}
+		})
+	}
+}
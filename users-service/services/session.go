@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"time"
+	"users-service/models"
+	"users-service/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session has been revoked")
+)
+
+// last seen times are only written this often to keep IsValidToken cheap
+const lastSeenResolution = time.Minute
+
+type SessionService interface {
+	// Create records a new session for the user and returns it, its
+	// TokenID goes into the jti claim of the access token.
+	Create(userID uint, userAgent, ip string) (*models.Session, error)
+	// Touch checks that the session of a token is still active and bumps
+	// its last seen time.
+	Touch(tokenID string, userID uint) error
+	// List returns the active sessions of the user, most recently used first.
+	List(userID uint) ([]models.Session, error)
+	// Revoke ends a session of the user.
+	Revoke(userID uint, id uint) (*models.Session, error)
+}
+
+func NewSessionService(db *gorm.DB) SessionService {
+	return &sessionService{
+		db: db,
+	}
+}
+
+// the user agent and address come from the client unchecked, they are cut
+// to the size of their columns
+const (
+	maxUserAgentLength = 512
+	maxIPLength        = 64
+)
+
+type sessionService struct {
+	db *gorm.DB
+}
+
+func (s *sessionService) Create(userID uint, userAgent, ip string) (*models.Session, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	session := models.Session{
+		UserID:     userID,
+		TokenID:    hex.EncodeToString(b),
+		UserAgent:  utils.Truncate(userAgent, maxUserAgentLength),
+		IP:         utils.Truncate(ip, maxIPLength),
+		LastSeenAt: time.Now(),
+	}
+	err := s.db.Create(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (s *sessionService) Touch(tokenID string, userID uint) error {
+	if tokenID == "" {
+		return ErrSessionNotFound
+	}
+
+	var session models.Session
+	err := s.db.Where("token_id = ? AND user_id = ?", tokenID, userID).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	if session.RevokedAt != nil {
+		return ErrSessionRevoked
+	}
+
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) < lastSeenResolution {
+		return nil
+	}
+	return s.db.Model(&session).Update("last_seen_at", now).Error
+}
+
+func (s *sessionService) List(userID uint) ([]models.Session, error) {
+	sessions := []models.Session{}
+	err := s.db.Where("user_id = ? AND revoked_at IS NULL", userID).Order("last_seen_at DESC").Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *sessionService) Revoke(userID uint, id uint) (*models.Session, error) {
+	var session models.Session
+	err := s.db.Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	session.RevokedAt = &now
+	err = s.db.Model(&session).Update("revoked_at", &now).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// TokenIDFromContext returns the session token id of the access token the
+// call was made with, or an empty string.
+func TokenIDFromContext(ctx context.Context) string {
+	token, err := extractAuthToken(ctx)
+	if err != nil {
+		return ""
+	}
+
+	t, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	})
+	if err != nil || !t.Valid {
+		return ""
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	tokenID, _ := claims["jti"].(string)
+	return tokenID
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+)
+
+// SMSSender delivers text messages. Implementations wrap an SMS provider.
+type SMSSender interface {
+	Send(ctx context.Context, to, message string) error
+}
+
+// NewSMSSender returns the sender selected by SMS_PROVIDER. Only "log" is
+// available for now, real providers plug in here.
+func NewSMSSender() SMSSender {
+	provider := os.Getenv("SMS_PROVIDER")
+	switch provider {
+	case "", "log":
+		return &logSMSSender{}
+	default:
+		log.Fatalf("unknown SMS_PROVIDER '%s'", provider)
+		return nil
+	}
+}
+
+// logSMSSender writes messages to the service log instead of sending them,
+// for local development.
+type logSMSSender struct{}
+
+func (l *logSMSSender) Send(ctx context.Context, to, message string) error {
+	log.Printf("SMS to %s: %s", to, message)
+	return nil
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"contracts/auth"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"log"
@@ -18,14 +20,16 @@ type UserService interface {
 	ValidateAndGetUser(c context.Context) (*models.User, error)
 }
 
-func NewUserService(db *gorm.DB) UserService {
+func NewUserService(db *gorm.DB, sessionService SessionService) UserService {
 	return &userService{
-		db: db,
+		db:             db,
+		sessionService: sessionService,
 	}
 }
 
 type userService struct {
-	db *gorm.DB
+	db             *gorm.DB
+	sessionService SessionService
 }
 
 func (u *userService) IsValidToken(token string) (*models.User, error) {
@@ -40,7 +44,7 @@ func (u *userService) IsValidToken(token string) (*models.User, error) {
 		return []byte(key), nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid token '%s',", key)
+		return nil, fmt.Errorf("invalid token '%s',", err.Error())
 	}
 
 	if !t.Valid {
@@ -53,11 +57,23 @@ func (u *userService) IsValidToken(token string) (*models.User, error) {
 		return nil, errors.New("invalid token, invalid claims")
 	}
 
+	// MFA challenge tokens are not access tokens
+	if _, ok := claims["mfa"]; ok {
+		return nil, errors.New("invalid token, mfa challenge")
+	}
+
 	err = u.db.Where("email = ?", claims["email"]).First(&user).Error
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
 
+	// tokens of revoked sessions are refused
+	tokenID, _ := claims["jti"].(string)
+	err = u.sessionService.Touch(tokenID, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token, %s", err.Error())
+	}
+
 	return &user, nil
 }
 
@@ -76,12 +92,42 @@ func extractAuthToken(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("hehehe")
 	}
 
-	log.Printf("auth_token found '%s'", tokens[0])
 	// Return the first token (in case there are multiple)
 	return tokens[0], nil
 }
 
+// isServiceCall reports whether the caller is another backend service
+// authenticated with the shared SERVICE_TOKEN.
+func isServiceCall(ctx context.Context) bool {
+	serviceToken := os.Getenv("SERVICE_TOKEN")
+	if serviceToken == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	tokens := md["service_token"]
+	return len(tokens) > 0 && subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(serviceToken)) == 1
+}
+
+// UserFromContext returns the user stored by the auth interceptor, if any.
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	return auth.UserFromContext[*models.User](ctx)
+}
+
 func (u *userService) ValidateAndGetUser(c context.Context) (*models.User, error) {
+	// already authenticated by the auth interceptor
+	if user, ok := UserFromContext(c); ok {
+		return user, nil
+	}
+
+	if isServiceCall(c) {
+		return &models.User{Username: "service", Role: models.RoleService}, nil
+	}
+
 	// extract token
 	token, err := extractAuthToken(c)
 	if err != nil {
@@ -0,0 +1,9 @@
+package utils
+
+import "strings"
+
+// NormalizeEmail returns the form emails are stored and looked up in, so
+// addresses differing only by case or surrounding spaces are one account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
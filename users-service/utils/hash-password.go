@@ -1,15 +1,208 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hashing algorithms. Hashes are stored in their standard encoded
+// format ("$argon2id$v=19$m=...,t=...,p=...$salt$key" and "$2a$cost$...")
+// so the algorithm and parameters of every hash are known when checking it.
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// PasswordHashParams configures how new password hashes are created. Hashes
+// made with other parameters still verify, and are rehashed on login.
+type PasswordHashParams struct {
+	Algorithm string
+	// argon2id memory in KiB, iterations and parallelism
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	// bcrypt cost
+	Cost int
+}
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// DefaultPasswordHashParams follow the OWASP recommendations.
+var DefaultPasswordHashParams = PasswordHashParams{
+	Algorithm: AlgorithmArgon2id,
+	Memory:    19 * 1024,
+	Time:      2,
+	Threads:   1,
+	Cost:      12,
+}
+
+var (
+	hashParams     PasswordHashParams
+	hashParamsOnce sync.Once
+)
+
+// currentHashParams reads the parameters from PASSWORD_HASH_ALGORITHM,
+// ARGON2_MEMORY, ARGON2_TIME, ARGON2_THREADS and BCRYPT_COST once, falling
+// back to DefaultPasswordHashParams.
+func currentHashParams() PasswordHashParams {
+	hashParamsOnce.Do(func() {
+		hashParams = DefaultPasswordHashParams
+		if algo := os.Getenv("PASSWORD_HASH_ALGORITHM"); algo != "" {
+			hashParams.Algorithm = algo
+		}
+		hashParams.Memory = uint32(envInt("ARGON2_MEMORY", int(hashParams.Memory)))
+		hashParams.Time = uint32(envInt("ARGON2_TIME", int(hashParams.Time)))
+		hashParams.Threads = uint8(envInt("ARGON2_THREADS", int(hashParams.Threads)))
+		hashParams.Cost = envInt("BCRYPT_COST", hashParams.Cost)
+
+		if hashParams.Algorithm != AlgorithmArgon2id && hashParams.Algorithm != AlgorithmBcrypt {
+			log.Printf("unknown PASSWORD_HASH_ALGORITHM '%s', using %s", hashParams.Algorithm, AlgorithmArgon2id)
+			hashParams.Algorithm = AlgorithmArgon2id
+		}
+	})
+	return hashParams
+}
+
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		log.Printf("invalid %s '%s', using %d", name, raw, fallback)
+		return fallback
+	}
+	return v
+}
 
 // HashPassword hashes the provided password
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+	params := currentHashParams()
+	if params.Algorithm == AlgorithmBcrypt {
+		bytes, err := bcrypt.GenerateFromPassword([]byte(password), params.Cost)
+		return string(bytes), err
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+var (
+	dummyHash     string
+	dummyHashOnce sync.Once
+)
+
+// DummyPasswordHash is a hash of no user's password, made with the current
+// parameters. Checking passwords against it takes as long as checking them
+// against a user's hash.
+func DummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		var err error
+		dummyHash, err = HashPassword("not the password of any user")
+		if err != nil {
+			log.Printf("failed to hash the dummy password: %v", err)
+		}
+	})
+	return dummyHash
 }
 
 // CheckPasswordHash checks if the provided password matches the hashed password
 func CheckPasswordHash(password, hash string) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		h, err := decodeArgon2Hash(hash)
+		if err != nil {
+			return false
+		}
+		key := argon2.IDKey([]byte(password), h.salt, h.params.Time, h.params.Memory, h.params.Threads, uint32(len(h.key)))
+		return subtle.ConstantTimeCompare(key, h.key) == 1
+	}
+
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// NeedsRehash reports whether hash was made with another algorithm or other
+// parameters than the current ones.
+func NeedsRehash(hash string) bool {
+	params := currentHashParams()
+
+	if strings.HasPrefix(hash, "$argon2id$") {
+		h, err := decodeArgon2Hash(hash)
+		if err != nil || params.Algorithm != AlgorithmArgon2id {
+			return true
+		}
+		return h.version != argon2.Version ||
+			h.params.Memory != params.Memory ||
+			h.params.Time != params.Time ||
+			h.params.Threads != params.Threads
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil || params.Algorithm != AlgorithmBcrypt {
+		return true
+	}
+	return cost != params.Cost
+}
+
+type argon2Hash struct {
+	version int
+	params  PasswordHashParams
+	salt    []byte
+	key     []byte
+}
+
+var errInvalidHash = errors.New("invalid password hash")
+
+func decodeArgon2Hash(hash string) (*argon2Hash, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, errInvalidHash
+	}
+
+	h := &argon2Hash{}
+	_, err := fmt.Sscanf(parts[2], "v=%d", &h.version)
+	if err != nil {
+		return nil, errInvalidHash
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.params.Memory, &h.params.Time, &h.params.Threads)
+	if err != nil {
+		return nil, errInvalidHash
+	}
+
+	h.salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, errInvalidHash
+	}
+
+	h.key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(h.key) == 0 {
+		return nil, errInvalidHash
+	}
+	return h, nil
+}
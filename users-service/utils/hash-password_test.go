@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// useHashParams makes params the current hashing parameters for the test.
+func useHashParams(t *testing.T, params PasswordHashParams) {
+	t.Helper()
+	hashParamsOnce.Do(func() {})
+	previous := hashParams
+	hashParams = params
+	t.Cleanup(func() { hashParams = previous })
+}
+
+var (
+	testArgon2Params = PasswordHashParams{Algorithm: AlgorithmArgon2id, Memory: 64, Time: 1, Threads: 1, Cost: bcrypt.MinCost}
+	testBcryptParams = PasswordHashParams{Algorithm: AlgorithmBcrypt, Memory: 64, Time: 1, Threads: 1, Cost: bcrypt.MinCost}
+)
+
+func TestHashPassword(t *testing.T) {
+	for _, params := range []PasswordHashParams{testArgon2Params, testBcryptParams} {
+		t.Run(params.Algorithm, func(t *testing.T) {
+			useHashParams(t, params)
+
+			hash, err := HashPassword("correct horse battery")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !CheckPasswordHash("correct horse battery", hash) {
+				t.Error("the password doesn't match its hash")
+			}
+			if CheckPasswordHash("wrong horse battery", hash) {
+				t.Error("another password matches the hash")
+			}
+			if NeedsRehash(hash) {
+				t.Error("a hash made with the current parameters needs a rehash")
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	useHashParams(t, testBcryptParams)
+	bcryptHash, err := HashPassword("correct horse battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useHashParams(t, testArgon2Params)
+	argon2Hash, err := HashPassword("correct horse battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stronger := testArgon2Params
+	stronger.Time = 2
+	costlier := testBcryptParams
+	costlier.Cost = bcrypt.MinCost + 1
+
+	tests := []struct {
+		name    string
+		current PasswordHashParams
+		hash    string
+		want    bool
+	}{
+		{name: "bcrypt hash, argon2id current", current: testArgon2Params, hash: bcryptHash, want: true},
+		{name: "argon2id hash, bcrypt current", current: testBcryptParams, hash: argon2Hash, want: true},
+		{name: "weaker argon2id parameters", current: stronger, hash: argon2Hash, want: true},
+		{name: "lower bcrypt cost", current: costlier, hash: bcryptHash, want: true},
+		{name: "malformed argon2id hash", current: testArgon2Params, hash: "$argon2id$v=19$garbage", want: true},
+		{name: "same argon2id parameters", current: testArgon2Params, hash: argon2Hash, want: false},
+		{name: "same bcrypt cost", current: testBcryptParams, hash: bcryptHash, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useHashParams(t, tt.current)
+			if got := NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// hashes of every algorithm keep verifying during the migration
+			if !strings.Contains(tt.name, "malformed") && !CheckPasswordHash("correct horse battery", tt.hash) {
+				t.Error("the password doesn't match its hash")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// passwords are limited in characters for users, and in bytes for bcrypt,
+// which only takes 72 bytes: characters outside ASCII take several
+const (
+	maxPasswordLength = 64
+	maxPasswordBytes  = 72
+)
+
+// default location of the breached password list, relative to the working
+// directory of the service
+const defaultBreachedPasswordsFile = "data/breached-passwords.txt"
+
+var ErrBreachedPassword = errors.New("password appears in a list of breached passwords, choose another one")
+
+var (
+	breachedPasswords     map[string]struct{}
+	breachedPasswordsOnce sync.Once
+)
+
+// ValidatePassword enforces the password policy: a length between
+// PASSWORD_MIN_LENGTH (8 by default) and 64 characters (72 bytes at most),
+// not containing the user's email or username, and not in the breached
+// password list.
+func ValidatePassword(password, email, username string) error {
+	minLength := envInt("PASSWORD_MIN_LENGTH", 8)
+	length := utf8.RuneCountInString(password)
+	if length < minLength {
+		return fmt.Errorf("password must be at least %d characters long", minLength)
+	}
+	if length > maxPasswordLength {
+		return fmt.Errorf("password must be at most %d characters long", maxPasswordLength)
+	}
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes long, characters outside ASCII take several bytes", maxPasswordBytes)
+	}
+
+	local, _, _ := strings.Cut(email, "@")
+	if containsFold(password, local) || containsFold(password, username) {
+		return errors.New("password must not contain your email or username")
+	}
+
+	if isBreachedPassword(password) {
+		return ErrBreachedPassword
+	}
+	return nil
+}
+
+// containsFold reports whether password contains part, ignoring case. Parts
+// too short to be meaningful are ignored.
+func containsFold(password, part string) bool {
+	if utf8.RuneCountInString(part) < 4 {
+		return false
+	}
+	return strings.Contains(strings.ToLower(password), strings.ToLower(part))
+}
+
+func isBreachedPassword(password string) bool {
+	breachedPasswordsOnce.Do(loadBreachedPasswords)
+
+	sum := sha1.Sum([]byte(password))
+	_, ok := breachedPasswords[hex.EncodeToString(sum[:])]
+	return ok
+}
+
+// loadBreachedPasswords reads BREACHED_PASSWORDS_FILE, one password per
+// line, or one SHA-1 hash per line in the "HASH" or "HASH:count" format of
+// the Have I Been Pwned downloads. Lines starting with # are ignored.
+func loadBreachedPasswords() {
+	breachedPasswords = map[string]struct{}{}
+
+	path := os.Getenv("BREACHED_PASSWORDS_FILE")
+	if path == "" {
+		path = defaultBreachedPasswordsFile
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("breached password list not loaded: %v", err)
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		if len(hash) == sha1.Size*2 {
+			if _, err := hex.DecodeString(hash); err == nil {
+				breachedPasswords[strings.ToLower(hash)] = struct{}{}
+				continue
+			}
+		}
+
+		sum := sha1.Sum([]byte(line))
+		breachedPasswords[hex.EncodeToString(sum[:])] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("failed to read breached password list: %v", err)
+	}
+	log.Printf("loaded %d breached passwords", len(breachedPasswords))
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "breached.txt")
+	// "password123" as a plain line, "letmein!2024" as a SHA-1 hash
+	err := os.WriteFile(list, []byte("# breached\npassword123\nBE4D12909111ECB815AC1224A9A738156A0B518B:3\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BREACHED_PASSWORDS_FILE", list)
+	breachedPasswordsOnce = sync.Once{}
+	t.Cleanup(func() { breachedPasswordsOnce = sync.Once{} })
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{name: "valid", password: "correct horse battery"},
+		{name: "too short", password: "short", wantErr: "at least 8 characters"},
+		{name: "64 characters", password: strings.Repeat("a", 63) + "b"},
+		{name: "too many characters", password: strings.Repeat("ab", 33), wantErr: "at most 64 characters"},
+		// 30 characters but 90 bytes, bcrypt would refuse it
+		{name: "too many bytes", password: strings.Repeat("日", 30), wantErr: "at most 72 bytes"},
+		{name: "contains the email", password: "my jessica password", wantErr: "email or username"},
+		{name: "contains the username", password: "i am JSMITH99 yes", wantErr: "email or username"},
+		{name: "breached", password: "password123", wantErr: "breached"},
+		{name: "breached hash", password: "letmein!2024", wantErr: "breached"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password, "jessica@example.com", "jsmith99")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("got error %q, want none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
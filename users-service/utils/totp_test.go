@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// the RFC 6238 test secret "12345678901234567890"
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	// the RFC 6238 SHA-1 test vector of 1111111109, truncated to 6 digits
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: testTOTPSecret, code: "081804", at: now, wantStep: step, wantOK: true},
+		{name: "previous step", secret: testTOTPSecret, code: "081804", at: now.Add(totpPeriod * time.Second), wantStep: step, wantOK: true},
+		{name: "next step", secret: testTOTPSecret, code: "081804", at: now.Add(-totpPeriod * time.Second), wantStep: step, wantOK: true},
+		{name: "outside the window", secret: testTOTPSecret, code: "081804", at: now.Add(2 * totpPeriod * time.Second)},
+		{name: "wrong code", secret: testTOTPSecret, code: "081805", at: now},
+		{name: "short code", secret: testTOTPSecret, code: "81804", at: now},
+		{name: "invalid secret", secret: "not base32!", code: "081804", at: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := ValidateTOTP(tt.secret, tt.code, tt.at)
+			if ok != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("got step %d, %v, want step %d, %v", gotStep, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestValidateTOTPStepReplay checks a code reports the step it was issued
+// for wherever it falls in the window, so a used step can't be replayed
+// with a later clock.
+func TestValidateTOTPStepReplay(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / totpPeriod
+	code := totpCode([]byte("12345678901234567890"), step)
+
+	first, ok := ValidateTOTP(testTOTPSecret, code, now)
+	if !ok {
+		t.Fatal("the code of the current step was rejected")
+	}
+	replayed, ok := ValidateTOTP(testTOTPSecret, code, now.Add(totpPeriod*time.Second))
+	if !ok {
+		t.Fatal("the code of the previous step was rejected")
+	}
+	if replayed != first {
+		t.Errorf("the replayed code matched step %d, want %d", replayed, first)
+	}
+
+	next := totpCode([]byte("12345678901234567890"), step+1)
+	if got, _ := ValidateTOTP(testTOTPSecret, next, now); got <= first {
+		t.Errorf("the code of the next step matched step %d, want a step after %d", got, first)
+	}
+}
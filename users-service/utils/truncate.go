@@ -0,0 +1,14 @@
+package utils
+
+import "unicode/utf8"
+
+// Truncate cuts s to n bytes at most, on a UTF-8 boundary.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}